@@ -2,23 +2,42 @@ package internal
 
 import (
 	"fmt"
+	"hinoki-cli/internal/config"
 	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/repository"
 	"hinoki-cli/internal/screens"
+	"hinoki-cli/internal/screens/archive"
+	"hinoki-cli/internal/screens/backups"
+	"hinoki-cli/internal/screens/calendar"
+	"hinoki-cli/internal/screens/dashboard"
 	"hinoki-cli/internal/screens/goaldetails"
 	"hinoki-cli/internal/screens/hierarchy"
+	"hinoki-cli/internal/screens/inbox"
 	"hinoki-cli/internal/screens/overdue"
+	"hinoki-cli/internal/screens/review"
 	"hinoki-cli/internal/screens/search"
+	"hinoki-cli/internal/screens/statistics"
 	"hinoki-cli/internal/screens/timeframe"
+	"hinoki-cli/internal/screens/workspaces"
+	"hinoki-cli/internal/settings"
 	"log"
+	"os"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 const startupDelay = time.Second
 
 type model struct {
 	navigation screens.Navigation
+	keys       appKeyMap
+
+	showHelp  bool // The help overlay is shown over the current screen
+	showHints bool // A line of key hints is shown under every screen
 
 	width  int
 	height int
@@ -43,12 +62,41 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		if currentScreen != nil {
-			currentScreen.SetSize(m.width, m.height)
+			currentScreen.SetSize(m.width, m.screenHeight())
 		}
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c":
+		// Any key closes the help overlay without reaching the screen
+		if m.showHelp {
+			m.showHelp = false
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
 			return m, tea.Quit
+		case key.Matches(msg, m.keys.quickCapture):
+			// Quick capture works everywhere once the database is ready
+			switch screen := currentScreen.(type) {
+			case *screens.StartupModel, nil:
+			case *inbox.InboxScreen:
+				screen.Capture()
+				return m, nil
+			default:
+				return m, func() tea.Msg {
+					return screens.OpenInboxScreen{Capture: true}
+				}
+			}
+		case key.Matches(msg, m.keys.showHelp):
+			if _, ok := currentScreen.(screens.HelpProvider); ok && !isTypedIntoInput(msg, currentScreen) {
+				m.showHelp = true
+				return m, nil
+			}
+		case key.Matches(msg, m.keys.toggleHints):
+			if _, ok := currentScreen.(screens.HelpProvider); ok && !isTypedIntoInput(msg, currentScreen) {
+				m.showHints = !m.showHints
+				currentScreen.SetSize(m.width, m.screenHeight())
+				return m, nil
+			}
 		}
 	}
 
@@ -67,26 +115,75 @@ func (m model) View() string {
 		return ""
 	}
 
+	provider, ok := currentScreen.(screens.HelpProvider)
+	if !ok {
+		return currentScreen.View()
+	}
+
+	general := screens.KeyGroup{Title: "General", Bindings: m.keys.helpKeys()}
+
+	if m.showHelp {
+		return screens.HelpOverlay(append(provider.HelpKeys(), general), m.width, m.height)
+	}
+
+	if m.showHints {
+		// The general keys come first, so the way to the full help is never cut off
+		hints := append([]screens.KeyGroup{general}, provider.HelpKeys()...)
+		return lipgloss.JoinVertical(lipgloss.Left, currentScreen.View(), screens.HintBar(hints, m.width))
+	}
+
 	return currentScreen.View()
 }
 
+// screenHeight returns the height left to screens under the key hints
+func (m model) screenHeight() int {
+	if m.showHints {
+		return max(m.height-1, 0)
+	}
+	return m.height
+}
+
+// isTypedIntoInput reports whether the key is text typed into an input of the screen.
+// Keys that can't be typed, such as F1, still work while typing
+func isTypedIntoInput(msg tea.KeyMsg, screen screens.Screen) bool {
+	if msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace {
+		return false
+	}
+
+	capturer, ok := screen.(screens.InputCapturer)
+	return ok && capturer.IsCapturingInput()
+}
+
 func (m model) handleNavigation(msg tea.Msg) tea.Cmd {
 	cmds := make([]tea.Cmd, 0)
 
 	switch msg := msg.(type) {
 	case AppLaunchStart:
-		startupScreen := screens.NewStartupScreen(startupDelay)
-		startupScreen.SetSize(m.width, m.height)
+		delay := startupDelay
+		if startup, _ := config.GetStartupSettings(); !startup.Splash {
+			delay = 0
+		}
+
+		startupScreen := screens.NewStartupScreen(delay)
+		startupScreen.SetSize(m.width, m.screenHeight())
 		cmds = append(cmds,
 			startupScreen.Init(),
-			startupDelayCmd(startupDelay),
+			startupDelayCmd(delay),
 		)
 		m.navigation.Push(startupScreen)
 	case screens.OpenTimeframeScreen:
 		timeframeScreen := timeframe.NewTimeframeScreen()
-		timeframeScreen.SetSize(m.width, m.height)
+		timeframeScreen.SetSize(m.width, m.screenHeight())
 		cmds = append(cmds, timeframeScreen.Init())
 		m.navigation.Replace(timeframeScreen)
+
+		// The dashboard opens over the timeframe screen, which going back returns to
+		if startup, _ := config.GetStartupSettings(); startup.Screen == "dashboard" {
+			dashboardScreen := dashboard.NewDashboardScreen()
+			dashboardScreen.SetSize(m.width, m.screenHeight())
+			cmds = append(cmds, dashboardScreen.Init())
+			m.navigation.Push(dashboardScreen)
+		}
 	case screens.OpenTimeframeScreenWithGoal:
 		timeframeScreen := timeframe.NewTimeframeScreen()
 		if ts, ok := timeframeScreen.(*timeframe.TimeframeScreen); ok {
@@ -95,36 +192,86 @@ func (m model) handleNavigation(msg tea.Msg) tea.Cmd {
 				ts.SetSelectedGoalID(msg.GoalID)
 			}
 		}
-		timeframeScreen.SetSize(m.width, m.height)
+		timeframeScreen.SetSize(m.width, m.screenHeight())
 		cmds = append(cmds, timeframeScreen.Init(), timeframeScreen.Refresh())
 		m.navigation.Replace(timeframeScreen)
 	case screens.OpenSearchScreen:
 		searchScreen := search.NewSearchScreen()
-		searchScreen.SetSize(m.width, m.height)
+		searchScreen.SetSize(m.width, m.screenHeight())
 		cmds = append(cmds, searchScreen.Init())
 		m.navigation.Push(searchScreen)
 	case screens.OpenSearchScreenForParent:
 		searchScreen := search.NewSearchScreenForParentAssignment(msg.GoalID)
-		searchScreen.SetSize(m.width, m.height)
+		searchScreen.SetSize(m.width, m.screenHeight())
 		cmds = append(cmds, searchScreen.Init())
 		m.navigation.Push(searchScreen)
 	case screens.OpenOverdueScreen:
 		overdueScreen := overdue.NewOverdueScreen()
-		overdueScreen.SetSize(m.width, m.height)
+		overdueScreen.SetSize(m.width, m.screenHeight())
 		cmds = append(cmds, overdueScreen.Init())
 		m.navigation.Push(overdueScreen)
 	case screens.OpenHierarchyScreen:
 		hierarchyScreen := hierarchy.NewHierarchyScreen(msg.Goal)
-		hierarchyScreen.SetSize(m.width, m.height)
+		hierarchyScreen.SetSize(m.width, m.screenHeight())
 		cmds = append(cmds, hierarchyScreen.Init())
 		m.navigation.Push(hierarchyScreen)
 	case screens.OpenGoalDetailsScreen:
 		goalDetailsScreen := goaldetails.NewGoalDetailsScreen(msg.Goal)
-		goalDetailsScreen.SetSize(m.width, m.height)
+		goalDetailsScreen.SetSize(m.width, m.screenHeight())
 		cmds = append(cmds, goalDetailsScreen.Init())
 		m.navigation.Push(goalDetailsScreen)
+	case screens.OpenInboxScreen:
+		inboxScreen := inbox.NewInboxScreen(msg.Capture)
+		inboxScreen.SetSize(m.width, m.screenHeight())
+		cmds = append(cmds, inboxScreen.Init())
+		m.navigation.Push(inboxScreen)
+	case screens.OpenArchiveScreen:
+		archiveScreen := archive.NewArchiveScreen()
+		archiveScreen.SetSize(m.width, m.screenHeight())
+		cmds = append(cmds, archiveScreen.Init())
+		m.navigation.Push(archiveScreen)
+	case screens.OpenWorkspacesScreen:
+		workspacesScreen := workspaces.NewWorkspacesScreen()
+		workspacesScreen.SetSize(m.width, m.screenHeight())
+		cmds = append(cmds, workspacesScreen.Init())
+		m.navigation.Push(workspacesScreen)
+	case screens.OpenDashboardScreen:
+		dashboardScreen := dashboard.NewDashboardScreen()
+		dashboardScreen.SetSize(m.width, m.screenHeight())
+		cmds = append(cmds, dashboardScreen.Init())
+		m.navigation.Push(dashboardScreen)
+	case screens.OpenCalendarScreen:
+		calendarScreen := calendar.NewCalendarScreen(msg.Date)
+		calendarScreen.SetSize(m.width, m.screenHeight())
+		cmds = append(cmds, calendarScreen.Init())
+		m.navigation.Push(calendarScreen)
+	case screens.OpenStatsScreen:
+		statsScreen := statistics.NewStatisticsScreen()
+		statsScreen.SetSize(m.width, m.screenHeight())
+		cmds = append(cmds, statsScreen.Init())
+		m.navigation.Push(statsScreen)
+	case screens.OpenReviewScreen:
+		reviewScreen := review.NewReviewScreen(msg.Timeframe, msg.Date)
+		reviewScreen.SetSize(m.width, m.screenHeight())
+		cmds = append(cmds, reviewScreen.Init())
+		m.navigation.Push(reviewScreen)
+	case screens.WorkspaceSwitched:
+		timeframeScreen := timeframe.NewTimeframeScreen()
+		timeframeScreen.SetSize(m.width, m.screenHeight())
+		cmds = append(cmds, timeframeScreen.Init())
+		if ts, ok := timeframeScreen.(*timeframe.TimeframeScreen); ok && msg.Message != "" {
+			cmds = append(cmds, ts.ShowMessage(msg.Message))
+		}
+		m.navigation.Reset(timeframeScreen)
+	case screens.OpenBackupsScreen:
+		backupsScreen := backups.NewBackupsScreen()
+		backupsScreen.SetSize(m.width, m.screenHeight())
+		cmds = append(cmds, backupsScreen.Init())
+		m.navigation.Push(backupsScreen)
 	case screens.GoBack:
 		m.navigation.Pop()
+		// Key hints may have been toggled while the screen was covered
+		m.navigation.Top().SetSize(m.width, m.screenHeight())
 		cmds = append(cmds, m.navigation.Top().Refresh())
 	}
 
@@ -132,13 +279,30 @@ func (m model) handleNavigation(msg tea.Msg) tea.Cmd {
 }
 
 func CreateApp() {
-	defer db.CloseDB()
+	if err := settings.Apply(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := keymap.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
-	p := tea.NewProgram(model{navigation: &screens.NavigationState{}}, tea.WithAltScreen())
+	p := tea.NewProgram(model{navigation: &screens.NavigationState{}, keys: newAppKeyMap()}, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
 		log.Fatal(err)
 	}
+
+	// Quitting on the splash screen can come before the database is open
+	if db.IsOpen() {
+		if _, err := db.CreateScheduledBackup(db.BackupOnExit); err != nil {
+			fmt.Fprintf(os.Stderr, "Automatic backup failed: %v\n", err)
+		}
+	}
+
+	db.CloseDB()
 }
 
 func startupDelayCmd(duration time.Duration) tea.Cmd {
@@ -147,6 +311,12 @@ func startupDelayCmd(duration time.Duration) tea.Cmd {
 	dbCmd := func() tea.Msg {
 		db.InitDB()
 
+		// A failed automatic backup must not keep the planner from starting
+		db.CreateScheduledBackup(db.BackupOnStartup)
+
+		// Purge after the backup, so goals purged by mistake can still be restored
+		repository.PurgeExpiredGoals()
+
 		ch <- 1
 
 		return nil
@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/settings"
+)
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string) error
+	// standalone commands run without the database, and even when the config file is invalid
+	standalone bool
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{
+			name:    "add",
+			usage:   "add [--date <date>] [--timeframe <timeframe>] [--parent <id>] [--repeat <rule>] [--inbox] <title> [#tag...]",
+			summary: "Create a new goal",
+			run:     runAdd,
+		},
+		{
+			name:    "list",
+			usage:   "list [--date <date>] [--timeframe <timeframe>] [--json]",
+			summary: "List goals of a period",
+			run:     runList,
+		},
+		{
+			name:    "search",
+			usage:   "search [--limit <n>] [--json] [<term>] [#tag...]",
+			summary: "Search goals by title, notes and tags",
+			run:     runSearch,
+		},
+		{
+			name:    "overdue",
+			usage:   "overdue [--json]",
+			summary: "List unfinished goals of past periods",
+			run:     runOverdue,
+		},
+		{
+			name:    "inbox",
+			usage:   "inbox [--json]",
+			summary: "List goals without a period",
+			run:     runInbox,
+		},
+		{
+			name:    "hierarchy",
+			usage:   "hierarchy [--json] <id>",
+			summary: "Show the ancestors and subgoals of a goal",
+			run:     runHierarchy,
+		},
+		{
+			name:    "stats",
+			usage:   "stats [--periods <n>] [--json]",
+			summary: "Show completion rates, streaks and overdue goals",
+			run:     runStats,
+		},
+		{
+			name:    "done",
+			usage:   "done <id>",
+			summary: "Mark a goal done",
+			run:     runDone,
+		},
+		{
+			name:    "move",
+			usage:   "move <id> <date>",
+			summary: "Move a goal to another period",
+			run:     runMove,
+		},
+		{
+			name:    "rollforward",
+			usage:   "rollforward [--date <date>] [--timeframe <timeframe>] [--overdue] [--yes] [--json]",
+			summary: "Move unfinished goals to the next period",
+			run:     runRollForward,
+		},
+		{
+			name:    "archive",
+			usage:   "archive <id>",
+			summary: "Archive a goal",
+			run:     runArchive,
+		},
+		{
+			name:    "backup",
+			usage:   "backup",
+			summary: "Back up the database and prune old backups",
+			run:     runBackup,
+		},
+		{
+			name:    "export",
+			usage:   "export [--output <file>]",
+			summary: "Export all goals to a JSON archive",
+			run:     runExport,
+		},
+		{
+			name:    "import",
+			usage:   "import [--mode merge|replace] <file>",
+			summary: "Import goals from a JSON archive",
+			run:     runImport,
+		},
+		{
+			name:       "config",
+			usage:      "config path | get [<name>] | set <name> <value>",
+			summary:    "Show or change settings",
+			run:        runConfig,
+			standalone: true,
+		},
+	}
+}
+
+// Run executes the subcommand named by args[0] and returns the process exit code
+func Run(args []string) int {
+	if len(args) == 0 || isHelpArg(args[0]) {
+		printUsage(os.Stdout)
+		return 0
+	}
+
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "hinoki: unknown command %q\n\n", args[0])
+		printUsage(os.Stderr)
+		return 2
+	}
+
+	if !cmd.standalone {
+		if err := settings.Apply(); err != nil {
+			fmt.Fprintf(os.Stderr, "hinoki: %v\n", err)
+			return 1
+		}
+
+		db.InitDB()
+		defer db.CloseDB()
+	}
+
+	if err := cmd.run(args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "hinoki %s: %v\n", cmd.name, err)
+		return 1
+	}
+
+	return 0
+}
+
+// ParseGlobalFlags applies the flags given before the command, --db and --workspace,
+// and returns the remaining arguments. HINOKI_DB is used when neither flag is given
+func ParseGlobalFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("hinoki", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() { printUsage(os.Stderr) }
+
+	dbFlag := fs.String("db", "", "database file to open instead of the workspace database")
+	workspaceFlag := fs.String("workspace", "", "workspace to open, created if it doesn't exist")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case *dbFlag != "" && *workspaceFlag != "":
+		return nil, fmt.Errorf("--db and --workspace can't be used together")
+	case *workspaceFlag != "":
+		if err := db.SetWorkspace(*workspaceFlag); err != nil {
+			return nil, fmt.Errorf("invalid --workspace: %w", err)
+		}
+	case *dbFlag != "":
+		db.SetPath(*dbFlag)
+	default:
+		db.SetPath(os.Getenv("HINOKI_DB"))
+	}
+
+	return fs.Args(), nil
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func isHelpArg(arg string) bool {
+	return arg == "help" || arg == "-h" || arg == "--help"
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  hinoki [--db <file> | --workspace <name>]             Open the planner")
+	fmt.Fprintln(w, "  hinoki [--db <file> | --workspace <name>] <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-12s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'hinoki <command> --help' for details.")
+}
+
+// newFlagSet creates a flag set for a command that reports errors instead of exiting
+func newFlagSet(cmd string) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	if c, ok := findCommand(cmd); ok {
+		fs.Usage = func() {
+			fmt.Fprintf(os.Stderr, "Usage: hinoki %s\n", c.usage)
+			fs.PrintDefaults()
+		}
+	}
+	return fs
+}
+
+// parseArgs parses flags that may appear before, after or between positional arguments
+// Returns the positional arguments in their original order
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+
+		// Everything after "--" is positional
+		if len(args) > 0 && len(rest) < len(args) && args[len(args)-len(rest)-1] == "--" {
+			return append(positional, rest...), nil
+		}
+
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// joinArgs joins positional arguments into a single space separated string
+func joinArgs(args []string) string {
+	return strings.TrimSpace(strings.Join(args, " "))
+}
+
+// flagsSet reports whether any of the named flags was given on the command line
+func flagsSet(fs *flag.FlagSet, names ...string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if slices.Contains(names, f.Name) {
+			set = true
+		}
+	})
+	return set
+}
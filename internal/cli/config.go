@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"hinoki-cli/internal/config"
+)
+
+func runConfig(args []string) error {
+	fs := newFlagSet("config")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) == 0 {
+		fs.Usage()
+		return fmt.Errorf("missing subcommand")
+	}
+
+	// Keep the settings of ~/.hinoki.rc when the config file is first written or read
+	if path, err := config.MigrateLegacy(); err != nil {
+		return err
+	} else if path != "" {
+		fmt.Fprintf(os.Stderr, "Moved settings from ~/.hinoki.rc to %s\n", path)
+	}
+
+	switch sub, rest := positional[0], positional[1:]; sub {
+	case "path":
+		if len(rest) != 0 {
+			return fmt.Errorf("usage: hinoki config path")
+		}
+		path, err := config.Path()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+	case "get":
+		switch len(rest) {
+		case 0:
+			all, err := config.All()
+			if err != nil {
+				return err
+			}
+			for _, s := range all {
+				fmt.Printf("%s = %s\n", s.Name, s.Value)
+			}
+		case 1:
+			value, err := config.Get(rest[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(value)
+		default:
+			return fmt.Errorf("usage: hinoki config get [<name>]")
+		}
+	case "set":
+		if len(rest) < 1 {
+			return fmt.Errorf("usage: hinoki config set <name> <value>")
+		}
+		return config.Set(rest[0], joinArgs(rest[1:]))
+	default:
+		return fmt.Errorf("unknown subcommand %q, expected path, get or set", sub)
+	}
+
+	return nil
+}
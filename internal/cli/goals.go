@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+const shortIDLength = 8
+
+func runAdd(args []string) error {
+	fs := newFlagSet("add")
+	dateFlag := fs.String("date", "today", "date or period of the goal, e.g. \"next week\"")
+	timeframeFlag := fs.String("timeframe", "", "timeframe of the goal (day, week, month, quarter, year, life)")
+	parentFlag := fs.String("parent", "", "id of the parent goal")
+	repeatFlag := fs.String("repeat", "", "repeat the goal (daily, weekdays, first-of-month, weekly, monthly, quarterly, yearly)")
+	inboxFlag := fs.Bool("inbox", false, "capture the goal to the inbox without a period")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	title, tags := goal.ParseTags(joinArgs(positional))
+	if title == "" {
+		return fmt.Errorf("goal title is required")
+	}
+
+	if *inboxFlag && *repeatFlag != "" {
+		return fmt.Errorf("inbox goals can't repeat")
+	}
+
+	var rule goal.Recurrence
+	if *repeatFlag != "" {
+		rule, err = goal.ParseRecurrence(*repeatFlag)
+		if err != nil {
+			return err
+		}
+		// The rule decides the timeframe unless it is given explicitly
+		if *timeframeFlag == "" {
+			*timeframeFlag = string(rule.Timeframe())
+		}
+	}
+
+	date, timeframe, err := resolvePeriod(*dateFlag, *timeframeFlag)
+	if err != nil {
+		return err
+	}
+
+	if rule != "" && rule.Timeframe() != timeframe {
+		return fmt.Errorf("%s goals can't repeat %s", string(timeframe), rule)
+	}
+
+	var parentID *string
+	if *parentFlag != "" {
+		parent, err := repository.GetGoalByIDPrefix(*parentFlag)
+		if err != nil {
+			return err
+		}
+		parentID = &parent.ID
+	}
+
+	g := goal.Goal{ID: uuid.New().String(), ParentId: parentID, Title: title, Tags: tags, Date: &date, Timeframe: &timeframe}
+	if *inboxFlag {
+		g.Date, g.Timeframe = nil, nil
+	}
+	if err := repository.AddGoal(g); err != nil {
+		return err
+	}
+
+	if rule != "" {
+		if err := repository.SetGoalRecurrence(g, rule); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(shortID(g.ID))
+	return nil
+}
+
+func runList(args []string) error {
+	fs := newFlagSet("list")
+	dateFlag := fs.String("date", "today", "date or period to list, e.g. \"next week\"")
+	timeframeFlag := fs.String("timeframe", "", "timeframe to list (day, week, month, quarter, year, life)")
+	jsonFlag := fs.Bool("json", false, "print goals as JSON")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	date, timeframe, err := resolvePeriod(*dateFlag, *timeframeFlag)
+	if err != nil {
+		return err
+	}
+
+	goals, err := repository.GetGoalsByDate(timeframe, date)
+	if err != nil {
+		return err
+	}
+
+	if err := repository.FillProgress(goals); err != nil {
+		return err
+	}
+
+	if *jsonFlag {
+		output := newGoalsOutput(kindList, goals)
+		output.Period = newPeriodOutput(timeframe, date)
+		return writeJSON(output)
+	}
+
+	fmt.Println(periodTitle(timeframe, date))
+	for _, g := range goals {
+		printGoal(g)
+	}
+
+	return nil
+}
+
+func runDone(args []string) error {
+	g, _, err := goalFromArgs("done", args)
+	if err != nil {
+		return err
+	}
+
+	g.IsDone = true
+	return repository.UpdateGoal(*g)
+}
+
+func runMove(args []string) error {
+	g, rest, err := goalFromArgs("move", args)
+	if err != nil {
+		return err
+	}
+
+	input := joinArgs(rest)
+	if input == "" {
+		return fmt.Errorf("target date is required")
+	}
+
+	date, timeframe, err := dates.ParseDate(time.Now(), input)
+	if err != nil {
+		return err
+	}
+
+	g.Date = &date
+	g.Timeframe = &timeframe
+	return repository.UpdateGoal(*g)
+}
+
+func runArchive(args []string) error {
+	g, _, err := goalFromArgs("archive", args)
+	if err != nil {
+		return err
+	}
+
+	g.IsArchived = true
+	return repository.UpdateGoal(*g)
+}
+
+// goalFromArgs resolves the goal referenced by the first positional argument
+// Returns the goal and the remaining positional arguments
+func goalFromArgs(cmd string, args []string) (*goal.Goal, []string, error) {
+	fs := newFlagSet(cmd)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(positional) == 0 {
+		return nil, nil, fmt.Errorf("goal id is required")
+	}
+
+	g, err := repository.GetGoalByIDPrefix(positional[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return g, positional[1:], nil
+}
+
+// resolvePeriod turns the --date and --timeframe flags into a period
+// The timeframe implied by the date is used unless one is given explicitly
+func resolvePeriod(dateInput string, timeframeInput string) (time.Time, goal.Timeframe, error) {
+	date, timeframe, err := dates.ParseDate(time.Now(), dateInput)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+
+	if timeframeInput != "" {
+		timeframe, err = parseTimeframe(timeframeInput)
+		if err != nil {
+			return time.Time{}, "", err
+		}
+	}
+
+	return date, timeframe, nil
+}
+
+func parseTimeframe(s string) (goal.Timeframe, error) {
+	switch tf := goal.Timeframe(s); tf {
+	case goal.Day, goal.Week, goal.Month, goal.Quarter, goal.Year, goal.Life:
+		return tf, nil
+	}
+	return "", fmt.Errorf("invalid timeframe: %s", s)
+}
+
+func periodTitle(timeframe goal.Timeframe, date time.Time) string {
+	title := timeframe.String()
+	if period := dates.DateString(date, timeframe); period != "" {
+		title = fmt.Sprintf("%s • %s", title, period)
+	}
+	return title
+}
+
+func printGoal(g goal.Goal) {
+	line := goalLine(g)
+	if g.ParentTitle != nil {
+		line = fmt.Sprintf("%s  (Parent: %s)", line, *g.ParentTitle)
+	}
+
+	fmt.Println(line)
+}
+
+func goalLine(g goal.Goal) string {
+	checkmark := " "
+	if g.IsDone {
+		checkmark = "x"
+	}
+
+	line := fmt.Sprintf("[%s] %s  %s", checkmark, shortID(g.ID), goal.TitleWithTags(g.Title, g.Tags))
+	if g.Recurrence != "" {
+		line += fmt.Sprintf(" (↻ %s)", g.Recurrence)
+	}
+	if g.Progress != nil {
+		line += fmt.Sprintf(" [%d/%d %.0f%%]", g.Progress.Done, g.Progress.Total, g.Progress.Ratio*100)
+	}
+	return line
+}
+
+func shortID(id string) string {
+	if len(id) <= shortIDLength {
+		return id
+	}
+	return id[:shortIDLength]
+}
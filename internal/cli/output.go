@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/repository"
+)
+
+// jsonSchemaVersion is bumped whenever the JSON output changes in a backwards incompatible way
+// Adding new fields is not considered a breaking change
+const jsonSchemaVersion = 1
+
+// Kinds of JSON documents, reported in the "kind" field so consumers can tell them apart
+const (
+	kindList      = "list"
+	kindSearch    = "search"
+	kindOverdue   = "overdue"
+	kindInbox     = "inbox"
+	kindHierarchy = "hierarchy"
+)
+
+type periodOutput struct {
+	Timeframe goal.Timeframe `json:"timeframe"`
+	Date      string         `json:"date"`
+	Label     string         `json:"label"`
+}
+
+type goalsOutput struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	Kind          string        `json:"kind"`
+	Period        *periodOutput `json:"period,omitempty"`
+	Query         *string       `json:"query,omitempty"`
+	Goals         []goal.Goal   `json:"goals"`
+	// PeriodNotes are the period journals matching a search
+	PeriodNotes []periodNoteOutput `json:"periodNotes,omitempty"`
+}
+
+type periodNoteOutput struct {
+	Period    *periodOutput `json:"period"`
+	Text      string        `json:"text"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+type hierarchyOutput struct {
+	SchemaVersion int         `json:"schemaVersion"`
+	Kind          string      `json:"kind"`
+	Goal          goal.Goal   `json:"goal"`
+	Ancestors     []goal.Goal `json:"ancestors"`
+	Children      []goal.Goal `json:"children"`
+}
+
+func newGoalsOutput(kind string, goals []goal.Goal) goalsOutput {
+	return goalsOutput{SchemaVersion: jsonSchemaVersion, Kind: kind, Goals: nonNilGoals(goals)}
+}
+
+func newPeriodOutput(timeframe goal.Timeframe, date time.Time) *periodOutput {
+	return &periodOutput{
+		Timeframe: timeframe,
+		Date:      dates.TimeframeDateString(date),
+		Label:     dates.DateString(date, timeframe),
+	}
+}
+
+func newPeriodNoteOutputs(notes []repository.PeriodNote) []periodNoteOutput {
+	outputs := make([]periodNoteOutput, 0, len(notes))
+	for _, note := range notes {
+		outputs = append(outputs, periodNoteOutput{
+			Period:    newPeriodOutput(note.Timeframe, note.Start),
+			Text:      note.Text,
+			UpdatedAt: note.UpdatedAt,
+		})
+	}
+	return outputs
+}
+
+// nonNilGoals makes empty results encode as [] instead of null
+func nonNilGoals(goals []goal.Goal) []goal.Goal {
+	if goals == nil {
+		return []goal.Goal{}
+	}
+	return goals
+}
+
+func writeJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/repository"
+)
+
+func runSearch(args []string) error {
+	fs := newFlagSet("search")
+	limitFlag := fs.Int("limit", 50, "maximum number of goals to return")
+	jsonFlag := fs.Bool("json", false, "print goals as JSON")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	query := joinArgs(positional)
+	term, tags := goal.ParseTags(query)
+	if term == "" && len(tags) == 0 {
+		return fmt.Errorf("search term is required")
+	}
+
+	goals, err := repository.SearchGoals(term, tags, *limitFlag)
+	if err != nil {
+		return err
+	}
+
+	// Period journals have no tags
+	var notes []repository.PeriodNote
+	if len(tags) == 0 {
+		if notes, err = repository.SearchPeriodNotes(term, *limitFlag); err != nil {
+			return err
+		}
+	}
+
+	if *jsonFlag {
+		output := newGoalsOutput(kindSearch, goals)
+		output.Query = &query
+		output.PeriodNotes = newPeriodNoteOutputs(notes)
+		return writeJSON(output)
+	}
+
+	for _, g := range goals {
+		printGoalWithPeriod(g)
+	}
+	for _, note := range notes {
+		firstLine, _, _ := strings.Cut(note.Text, "\n")
+		fmt.Printf("Journal   %s\n", firstLine)
+		fmt.Printf("    %s • %s\n", note.Timeframe.String(), dates.DateString(note.Start, note.Timeframe))
+	}
+	return nil
+}
+
+func runOverdue(args []string) error {
+	fs := newFlagSet("overdue")
+	jsonFlag := fs.Bool("json", false, "print goals as JSON")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	goals, err := repository.GetOverdueGoals()
+	if err != nil {
+		return err
+	}
+
+	if *jsonFlag {
+		return writeJSON(newGoalsOutput(kindOverdue, goals))
+	}
+
+	for _, g := range goals {
+		printGoalWithPeriod(g)
+	}
+	return nil
+}
+
+func runInbox(args []string) error {
+	fs := newFlagSet("inbox")
+	jsonFlag := fs.Bool("json", false, "print goals as JSON")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	goals, err := repository.GetUnscheduledGoals()
+	if err != nil {
+		return err
+	}
+
+	if *jsonFlag {
+		return writeJSON(newGoalsOutput(kindInbox, goals))
+	}
+
+	for _, g := range goals {
+		printGoal(g)
+	}
+	return nil
+}
+
+func runHierarchy(args []string) error {
+	fs := newFlagSet("hierarchy")
+	jsonFlag := fs.Bool("json", false, "print the hierarchy as JSON")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) == 0 {
+		return fmt.Errorf("goal id is required")
+	}
+
+	g, err := repository.GetGoalByIDPrefix(positional[0])
+	if err != nil {
+		return err
+	}
+
+	ancestors, err := repository.GetAncestors(g.ID)
+	if err != nil {
+		return err
+	}
+
+	chain := append(ancestors, *g)
+	if err := repository.FillProgress(chain); err != nil {
+		return err
+	}
+	ancestors, g = chain[:len(chain)-1], &chain[len(chain)-1]
+
+	children, err := repository.GetGoalsByParent(g.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := repository.FillProgress(children); err != nil {
+		return err
+	}
+
+	if *jsonFlag {
+		return writeJSON(hierarchyOutput{
+			SchemaVersion: jsonSchemaVersion,
+			Kind:          kindHierarchy,
+			Goal:          *g,
+			Ancestors:     nonNilGoals(ancestors),
+			Children:      nonNilGoals(children),
+		})
+	}
+
+	for i, ancestor := range ancestors {
+		fmt.Printf("%s%s\n", strings.Repeat("  ", i), goalLine(ancestor))
+	}
+
+	depth := len(ancestors)
+	fmt.Printf("%s%s\n", strings.Repeat("  ", depth), goalLine(*g))
+	for _, child := range children {
+		fmt.Printf("%s%s\n", strings.Repeat("  ", depth+1), goalLine(child))
+	}
+	return nil
+}
+
+// printGoalWithPeriod prints a goal followed by its timeframe and date
+func printGoalWithPeriod(g goal.Goal) {
+	printGoal(g)
+
+	if g.Timeframe == nil {
+		return
+	}
+
+	meta := g.Timeframe.String()
+	if g.Date != nil {
+		if period := dates.DateString(*g.Date, *g.Timeframe); period != "" {
+			meta = fmt.Sprintf("%s • %s", meta, period)
+		}
+	}
+	fmt.Printf("    %s\n", meta)
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/repository"
+)
+
+const kindRollForward = "rollforward"
+
+type moveOutput struct {
+	Goal goal.Goal     `json:"goal"`
+	To   *periodOutput `json:"to"`
+}
+
+type rollForwardOutput struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Kind          string `json:"kind"`
+	// Period is the period rolled forward, unset when rolling forward overdue goals
+	Period *periodOutput `json:"period,omitempty"`
+	// Applied is false for a preview, without --yes
+	Applied bool         `json:"applied"`
+	Moves   []moveOutput `json:"moves"`
+}
+
+func runRollForward(args []string) error {
+	fs := newFlagSet("rollforward")
+	dateFlag := fs.String("date", "today", "date or period to roll forward, e.g. \"yesterday\" or \"sep\"")
+	timeframeFlag := fs.String("timeframe", "", "timeframe of the period (day, week, month, quarter, year)")
+	overdueFlag := fs.Bool("overdue", false, "roll every overdue goal forward instead of a period")
+	yesFlag := fs.Bool("yes", false, "move the goals instead of showing where they would move")
+	jsonFlag := fs.Bool("json", false, "print the moves as JSON")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 0 {
+		return fmt.Errorf("unexpected argument %q, the period is given with --date", positional[0])
+	}
+
+	var goals []goal.Goal
+	var period *periodOutput
+
+	if *overdueFlag {
+		if flagsSet(fs, "date", "timeframe") {
+			return fmt.Errorf("--overdue can't be combined with --date or --timeframe")
+		}
+
+		overdue, err := repository.GetOverdueGoals()
+		if err != nil {
+			return err
+		}
+		goals = overdue
+	} else {
+		date, timeframe, err := resolvePeriod(*dateFlag, *timeframeFlag)
+		if err != nil {
+			return err
+		}
+		if timeframe == goal.Life {
+			return fmt.Errorf("life goals have no next period")
+		}
+
+		if goals, err = repository.GetGoalsByDate(timeframe, date); err != nil {
+			return err
+		}
+		period = newPeriodOutput(timeframe, date)
+	}
+
+	plan := repository.PlanRollForward(goals, time.Now())
+
+	if *yesFlag {
+		if _, err := repository.RollForward(plan); err != nil {
+			return err
+		}
+	}
+
+	if *jsonFlag {
+		output := rollForwardOutput{SchemaVersion: jsonSchemaVersion, Kind: kindRollForward, Period: period, Applied: *yesFlag, Moves: []moveOutput{}}
+		for _, p := range plan {
+			output.Moves = append(output.Moves, moveOutput{Goal: p.Goal, To: newPeriodOutput(*p.Goal.Timeframe, p.Date)})
+		}
+		return writeJSON(output)
+	}
+
+	for _, p := range plan {
+		timeframe := *p.Goal.Timeframe
+		printGoal(p.Goal)
+		fmt.Printf("    %s • %s → %s\n", timeframe.String(), dates.DateString(*p.Goal.Date, timeframe), dates.DateString(p.Date, timeframe))
+	}
+
+	noun := "goals"
+	if len(plan) == 1 {
+		noun = "goal"
+	}
+
+	switch {
+	case len(plan) == 0:
+		fmt.Println("No unfinished goals to roll forward")
+	case *yesFlag:
+		fmt.Printf("Rolled %d %s forward\n", len(plan), noun)
+	default:
+		fmt.Printf("Run again with --yes to roll %d %s forward\n", len(plan), noun)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"hinoki-cli/internal/stats"
+)
+
+const kindStats = "stats"
+
+type statsOutput struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Kind          string `json:"kind"`
+	stats.Report
+}
+
+func runStats(args []string) error {
+	fs := newFlagSet("stats")
+	periodsFlag := fs.Int("periods", stats.DefaultPeriods, "number of periods per timeframe, including the current one")
+	jsonFlag := fs.Bool("json", false, "print statistics as JSON")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *periodsFlag < 1 {
+		return fmt.Errorf("--periods must be at least 1")
+	}
+
+	report, err := stats.Load(time.Now(), *periodsFlag)
+	if err != nil {
+		return err
+	}
+
+	if *jsonFlag {
+		return writeJSON(statsOutput{SchemaVersion: jsonSchemaVersion, Kind: kindStats, Report: report})
+	}
+
+	fmt.Printf("Completion of the last %d periods, oldest first\n", report.Periods)
+	for _, c := range report.Completion {
+		done, total := c.Sum()
+		fmt.Printf("  %-8s %s  %s\n", c.Timeframe.String(), stats.Sparkline(c.Values(), 1), percent(done, total))
+	}
+
+	fmt.Printf("\nCompleted days: %d in a row, %d at most\n", report.Streaks.Current, report.Streaks.Longest)
+
+	values, top := report.OverdueValues()
+	fmt.Printf("\nOverdue goals at the end of the last %d weeks\n", report.Periods)
+	fmt.Printf("  %s  %d now\n", stats.Sparkline(values, top), report.Overdue[len(report.Overdue)-1].Count)
+
+	fmt.Println("\nGoals tracing up to a life goal")
+	for _, a := range report.LifeAlignment {
+		fmt.Printf("  %-8s %s\n", a.Timeframe.String(), percent(a.Linked, a.Total))
+	}
+
+	return nil
+}
+
+// percent formats part of total as e.g. "62% (31/50)"
+func percent(part, total int) string {
+	if total == 0 {
+		return "no goals"
+	}
+	return fmt.Sprintf("%d%% (%d/%d)", part*100/total, part, total)
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/export"
+)
+
+func runBackup(args []string) error {
+	fs := newFlagSet("backup")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	backupPath, err := db.CreateBackup()
+	if err != nil {
+		return err
+	}
+	fmt.Println(backupPath)
+
+	pruned, err := db.PruneBackups()
+	for _, name := range pruned {
+		fmt.Fprintf(os.Stderr, "Pruned %s\n", name)
+	}
+	return err
+}
+
+func runExport(args []string) error {
+	fs := newFlagSet("export")
+	outputFlag := fs.String("output", "-", "file to write the archive to, - for stdout")
+
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	doc, err := export.Build()
+	if err != nil {
+		return err
+	}
+
+	if *outputFlag == "-" {
+		return export.Write(os.Stdout, doc)
+	}
+
+	file, err := os.Create(*outputFlag)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := export.Write(file, doc); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d goals to %s\n", len(doc.Goals), *outputFlag)
+	return nil
+}
+
+func runImport(args []string) error {
+	fs := newFlagSet("import")
+	modeFlag := fs.String("mode", string(export.Merge), "merge (upsert by id) or replace (delete all goals first)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return fmt.Errorf("archive file is required, use - for stdin")
+	}
+
+	var input io.Reader = os.Stdin
+	if positional[0] != "-" {
+		file, err := os.Open(positional[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	doc, err := export.Read(input)
+	if err != nil {
+		return err
+	}
+
+	mode := export.Mode(*modeFlag)
+	if mode == export.Replace {
+		// Replacing is destructive, keep a copy of the current database around
+		backupPath, err := db.CreateBackup()
+		if err != nil {
+			return fmt.Errorf("failed to back up database before replacing: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Backup created: %s\n", backupPath)
+	}
+
+	if err := export.Import(doc, mode); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Imported %d goals (%s)\n", len(doc.Goals), mode)
+	return nil
+}
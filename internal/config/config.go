@@ -1,96 +1,269 @@
 package config
 
 import (
-	"bufio"
 	"fmt"
+	"hinoki-cli/internal/goal"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// GetBackupDir reads the backup directory from ~/.hinoki.rc
-// Creates the config file with default settings if it doesn't exist
-// Returns the backup directory path, or an error if unable to read or create
+// GetDatabasePath returns the database path set in the config file, or an empty string
+// to use the default location
+func GetDatabasePath() (string, error) {
+	f, err := current()
+	if err != nil {
+		return "", err
+	}
+
+	return expandHome(f.value("database.path"))
+}
+
+// GetBackupDir returns the directory backups are written to, ~/Documents/hinoki-backups by default
 func GetBackupDir() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	f, err := current()
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return "", err
 	}
 
-	configPath := filepath.Join(homeDir, ".hinoki.rc")
+	return expandHome(f.value("backup.dir"))
+}
 
-	// Check if config file exists, if not create it with defaults
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		defaultBackupDir := filepath.Join(homeDir, "Documents", "hinoki-backups")
-		configContent := fmt.Sprintf("# Hinoki Planner Configuration\n# Backup directory for database backups\nbackup_dir=%s\n", defaultBackupDir)
+// BackupSettings controls automatic backups and how many old backups are kept
+type BackupSettings struct {
+	// OnStartup creates a backup every time the planner is opened
+	OnStartup bool
+	// OnExit creates a backup every time the planner is closed
+	OnExit bool
+	// IntervalDays creates a backup on startup when the newest one is at least this many days old
+	IntervalDays int
 
-		if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
-			return "", fmt.Errorf("failed to create config file: %w", err)
-		}
+	// KeepDaily, KeepWeekly and KeepMonthly keep the newest backup of that many
+	// most recent days, weeks and months. When all are zero no backups are pruned
+	KeepDaily   int
+	KeepWeekly  int
+	KeepMonthly int
+}
 
-		// Return the default directory we just created
-		return defaultBackupDir, nil
+// HasRetention reports whether old backups should be pruned
+func (s BackupSettings) HasRetention() bool {
+	return s.KeepDaily > 0 || s.KeepWeekly > 0 || s.KeepMonthly > 0
+}
+
+// GetBackupSettings returns the automatic backup and retention settings
+// Missing settings default to no automatic backups and no pruning
+func GetBackupSettings() (BackupSettings, error) {
+	f, err := current()
+	if err != nil {
+		return BackupSettings{}, err
 	}
 
-	file, err := os.Open(configPath)
+	return BackupSettings{
+		OnStartup:    f.bool("backup.on_startup"),
+		OnExit:       f.bool("backup.on_exit"),
+		IntervalDays: f.int("backup.interval_days"),
+		KeepDaily:    f.int("backup.keep_daily"),
+		KeepWeekly:   f.int("backup.keep_weekly"),
+		KeepMonthly:  f.int("backup.keep_monthly"),
+	}, nil
+}
+
+type ProgressSettings struct {
+	// Weighted makes every direct child count equally in the progress of its parent
+	// instead of counting every descendant once
+	Weighted bool
+	// AutoCompleteParents marks a parent done once all of its children are done
+	AutoCompleteParents bool
+}
+
+// GetProgressSettings returns the roll-up progress settings
+func GetProgressSettings() (ProgressSettings, error) {
+	f, err := current()
 	if err != nil {
-		return "", fmt.Errorf("failed to open config file: %w", err)
+		return ProgressSettings{}, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		// Skip comments and empty lines
-		if strings.HasPrefix(line, "#") || line == "" {
-			continue
-		}
-		if strings.HasPrefix(line, "backup_dir=") {
-			dir := strings.TrimPrefix(line, "backup_dir=")
-			dir = strings.TrimSpace(dir)
-			// Expand ~ in path
-			if strings.HasPrefix(dir, "~/") {
-				dir = filepath.Join(homeDir, dir[2:])
+	return ProgressSettings{
+		Weighted:            f.bool("progress.weighted"),
+		AutoCompleteParents: f.bool("progress.auto_complete_parents"),
+	}, nil
+}
+
+type RollForwardSettings struct {
+	// CountPostponed counts on every goal how many times it was rolled forward
+	CountPostponed bool
+}
+
+// GetRollForwardSettings returns the settings of moving unfinished goals to the next period
+func GetRollForwardSettings() (RollForwardSettings, error) {
+	f, err := current()
+	if err != nil {
+		return RollForwardSettings{}, err
+	}
+
+	return RollForwardSettings{CountPostponed: f.bool("rollforward.count_postponed")}, nil
+}
+
+type ArchiveSettings struct {
+	// RetentionDays permanently deletes goals archived longer ago than this. Zero keeps them forever
+	RetentionDays int
+}
+
+// GetArchiveSettings returns the archive retention setting
+func GetArchiveSettings() (ArchiveSettings, error) {
+	f, err := current()
+	if err != nil {
+		return ArchiveSettings{}, err
+	}
+
+	return ArchiveSettings{RetentionDays: f.int("archive.retention_days")}, nil
+}
+
+type UISettings struct {
+	// Theme is the name of a built-in theme or of a palette
+	Theme string
+	// Palettes are the colors of the [palette.<name>] sections by name and role, e.g. "tag"
+	Palettes map[string]map[string]string
+}
+
+// GetUISettings returns the theme and the palettes defined in the config file
+func GetUISettings() (UISettings, error) {
+	ui := UISettings{Theme: "auto", Palettes: make(map[string]map[string]string)}
+
+	f, err := current()
+	if err != nil {
+		return ui, err
+	}
+
+	ui.Theme = strings.ToLower(f.value("ui.theme"))
+	for name, value := range f.values {
+		section, role := splitName(name)
+		if palette, ok := strings.CutPrefix(section, "palette."); ok {
+			palette = strings.ToLower(palette)
+			if ui.Palettes[palette] == nil {
+				ui.Palettes[palette] = make(map[string]string)
 			}
-			return dir, nil
+			ui.Palettes[palette][role] = value
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return "", fmt.Errorf("error reading config file: %w", err)
+	return ui, nil
+}
+
+type CalendarSettings struct {
+	// WeekStart is the first day of week periods
+	WeekStart time.Weekday
+	// Locale is the language of weekday names, "en" or "ja"
+	Locale string
+}
+
+// GetCalendarSettings returns the week start and date locale
+func GetCalendarSettings() (CalendarSettings, error) {
+	f, err := current()
+	if err != nil {
+		return CalendarSettings{}, err
 	}
 
-	// If backup_dir not found in existing config, add it
-	defaultBackupDir := filepath.Join(homeDir, "Documents", "hinoki-backups")
-	configContent := fmt.Sprintf("\n# Backup directory for database backups\nbackup_dir=%s\n", defaultBackupDir)
+	settings := CalendarSettings{WeekStart: time.Monday, Locale: strings.ToLower(f.value("calendar.locale"))}
+	for day, name := range weekdayNames {
+		if strings.EqualFold(name, f.value("calendar.week_start")) {
+			settings.WeekStart = time.Weekday(day)
+		}
+	}
 
-	file.Close() // Close the read file
+	return settings, nil
+}
 
-	// Append to existing config file
-	file, err = os.OpenFile(configPath, os.O_APPEND|os.O_WRONLY, 0644)
+type StartupSettings struct {
+	// Timeframe is the timeframe the planner opens with
+	Timeframe goal.Timeframe
+	// Splash shows the animated title while the database is opened
+	Splash bool
+	// Screen is the screen the planner opens with, "timeframe" or "dashboard"
+	Screen string
+}
+
+// GetStartupSettings returns how the planner starts
+func GetStartupSettings() (StartupSettings, error) {
+	f, err := current()
 	if err != nil {
-		return "", fmt.Errorf("failed to append to config file: %w", err)
+		return StartupSettings{Timeframe: goal.Day, Splash: true, Screen: "timeframe"}, err
 	}
-	defer file.Close()
 
-	if _, err := file.WriteString(configContent); err != nil {
-		return "", fmt.Errorf("failed to write to config file: %w", err)
-	}
+	return StartupSettings{
+		Timeframe: goal.Timeframe(strings.ToLower(f.value("startup.timeframe"))),
+		Splash:    f.bool("startup.splash"),
+		Screen:    strings.ToLower(f.value("startup.screen")),
+	}, nil
+}
+
+// DefaultKeyboardLayouts are the layouts whose alternate keys are bound when keys.layouts isn't set
+var DefaultKeyboardLayouts = []string{"ru"}
 
-	return defaultBackupDir, nil
+type KeySettings struct {
+	// Layouts add the keys typed in these keyboard layouts by the keys of every binding
+	Layouts []string
+	// Bindings replace the keys of actions, by action such as "timeframe.next_period".
+	// An empty list unbinds the action
+	Bindings map[string][]string
 }
 
-// GetBackupDirOrDefault returns the backup directory from config, or a default location
-func GetBackupDirOrDefault() (string, error) {
-	dir, err := GetBackupDir()
+// GetKeySettings returns the key bindings of the [keys] section. Keys are separated by spaces,
+// e.g. timeframe.next_period = l right, and the space bar is written as space
+func GetKeySettings() (KeySettings, error) {
+	keySettings := KeySettings{Bindings: make(map[string][]string)}
+
+	f, err := current()
 	if err != nil {
-		// Return default backup directory in user's Documents folder
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return "", fmt.Errorf("failed to get home directory: %w", err)
+		return keySettings, err
+	}
+
+	for _, layout := range strings.FieldsFunc(f.value("keys.layouts"), func(r rune) bool { return r == ',' || r == ' ' }) {
+		if layout != "none" {
+			keySettings.Layouts = append(keySettings.Layouts, strings.ToLower(layout))
+		}
+	}
+
+	for name, value := range f.values {
+		action, ok := strings.CutPrefix(name, "keys.")
+		if !ok || name == "keys.layouts" {
+			continue
+		}
+
+		keys := []string{}
+		for _, k := range strings.Fields(value) {
+			if k == "space" {
+				k = " "
+			}
+			keys = append(keys, k)
 		}
-		defaultDir := filepath.Join(homeDir, "Documents", "hinoki-backups")
-		return defaultDir, nil
+		keySettings.Bindings[action] = keys
+	}
+
+	return keySettings, nil
+}
+
+func (f *file) bool(name string) bool {
+	value, _ := strconv.ParseBool(f.value(name))
+	return value
+}
+
+func (f *file) int(name string) int {
+	value, _ := strconv.Atoi(f.value(name))
+	return value
+}
+
+// expandHome expands a leading ~/ in path to the home directory
+func expandHome(path string) (string, error) {
+	if !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
 	}
-	return dir, nil
+	return filepath.Join(homeDir, path[2:]), nil
 }
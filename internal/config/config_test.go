@@ -0,0 +1,123 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	content := `# Settings
+[backup]
+dir = ~/backups
+keep_daily = 7
+
+[keys]
+timeframe.next_period = l right
+`
+	f, err := parse("config.ini", strings.NewReader(content))
+	if err != nil || len(f.problems) != 0 {
+		t.Fatalf("parse: %v %v", err, f.problems)
+	}
+
+	if f.value("backup.keep_daily") != "7" || f.value("backup.keep_weekly") != "0" {
+		t.Errorf("values = %v; want keep_daily 7 and the default keep_weekly", f.values)
+	}
+	if f.lines["keys.timeframe.next_period"] != 7 {
+		t.Errorf("line of keys.timeframe.next_period = %d; want 7", f.lines["keys.timeframe.next_period"])
+	}
+}
+
+func TestParse_Problems(t *testing.T) {
+	content := `week_start = sunday
+[calendar]
+week_start = someday
+locale
+[colors]
+accent = red
+[backup]
+keep = 3
+on_exit = true
+on_exit = false
+`
+	f, err := parse("config.ini", strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, problem := range f.problems {
+		got = append(got, problem.Error())
+	}
+
+	expected := []string{
+		"config.ini:1: week_start is not in a [section]",
+		`config.ini:3: invalid week_start: "someday" is not one of sunday, monday, tuesday, wednesday, thursday, friday, saturday`,
+		`config.ini:4: expected key = value or [section], got "locale"`,
+		"config.ini:5: unknown section [colors], known sections are database, workspace, backup, progress, archive, rollforward, ui, palette.<name>, calendar, startup, keys",
+		"config.ini:8: unknown setting keep in [backup]",
+		"config.ini:10: on_exit is already set on line 9",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("problems =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	t.Setenv("HINOKI_BACKUP_KEEP_DAILY", "3")
+	t.Setenv("HINOKI_UI_THEME", "blue sky")
+
+	f, _ := parse("config.ini", strings.NewReader("[backup]\nkeep_daily = 7\n"))
+	f.applyEnv()
+
+	if f.value("backup.keep_daily") != "3" || f.fromEnv["backup.keep_daily"] != "HINOKI_BACKUP_KEEP_DAILY" {
+		t.Errorf("keep_daily = %s; want 3 from the environment", f.value("backup.keep_daily"))
+	}
+	if len(f.problems) != 1 || f.problems[0].Error() != `HINOKI_UI_THEME: "blue sky" is not a name of letters, digits, - and _` {
+		t.Errorf("problems = %v; want the invalid theme", f.problems)
+	}
+}
+
+func TestSetLine(t *testing.T) {
+	lines := []string{"# Settings", "[backup]", "dir = ~/backups", "", "[ui]", "theme = dark"}
+
+	lines = setLine(lines, "backup", "keep_daily", "7")
+	lines = setLine(lines, "ui", "theme", "light")
+	lines = setLine(lines, "calendar", "week_start", "sunday")
+
+	expected := []string{
+		"# Settings",
+		"[backup]", "dir = ~/backups", "keep_daily = 7", "",
+		"[ui]", "theme = light", "",
+		"[calendar]", "week_start = sunday",
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("setLine =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+// TestSet_EverySetting checks that every setting of the schema can be set and loaded again,
+// so that no setting lives in a section the file doesn't know
+func TestSet_EverySetting(t *testing.T) {
+	t.Setenv("HINOKI_CONFIG", filepath.Join(t.TempDir(), "config.ini"))
+	t.Cleanup(func() {
+		mu.Lock()
+		loaded = nil
+		mu.Unlock()
+	})
+
+	for _, name := range settingNames() {
+		value := schema[name].def
+		if value == "" {
+			value = "~/hinoki.db"
+		}
+		if err := Set(name, value); err != nil {
+			t.Fatalf("Set(%s) returned %v", name, err)
+		}
+	}
+
+	if err := Load(); err != nil {
+		t.Errorf("Load returned %v", err)
+	}
+}
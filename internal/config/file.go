@@ -0,0 +1,396 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Error is an invalid setting, located by the line of the config file or the environment
+// variable it was read from
+type Error struct {
+	Source string // Path of the config file or name of the environment variable
+	Line   int    // Line of the config file, zero for environment variables
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.Source, e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s", e.Source, e.Msg)
+}
+
+// file holds the settings read from the config file and the environment
+type file struct {
+	path     string
+	values   map[string]string
+	lines    map[string]int    // Line of each setting read from the config file
+	fromEnv  map[string]string // Environment variable of each setting overridden by one
+	problems []error
+}
+
+var (
+	mu     sync.Mutex
+	loaded *file // Settings read by the last load, nil until then
+)
+
+// Path returns the location of the config file: $HINOKI_CONFIG, or config.ini in the
+// hinoki-cli directory of the user config directory (~/.config on Linux)
+func Path() (string, error) {
+	if path := os.Getenv("HINOKI_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+
+	return filepath.Join(configDir, "hinoki-cli", "config.ini"), nil
+}
+
+// Load reads the config file and the environment again, and returns every invalid setting
+func Load() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	f, err := readFile()
+	if err != nil {
+		return err
+	}
+
+	loaded = f
+	return errors.Join(f.problems...)
+}
+
+// current returns the loaded settings, loading them on first use
+func current() (*file, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if loaded == nil {
+		f, err := readFile()
+		if err != nil {
+			return nil, err
+		}
+		loaded = f
+	}
+
+	if len(loaded.problems) > 0 {
+		return nil, errors.Join(loaded.problems...)
+	}
+	return loaded, nil
+}
+
+func readFile() (*file, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	var f *file
+
+	r, err := os.Open(path)
+	switch {
+	case os.IsNotExist(err):
+		f = &file{path: path, values: make(map[string]string), lines: make(map[string]int)}
+	case err != nil:
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	default:
+		defer r.Close()
+		if f, err = parse(path, r); err != nil {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+	}
+
+	f.applyEnv()
+	return f, nil
+}
+
+// parse reads the sections and settings of a config file. Invalid lines are collected
+// in problems instead of stopping the parse, so that all of them are reported at once
+func parse(path string, r io.Reader) (*file, error) {
+	f := &file{path: path, values: make(map[string]string), lines: make(map[string]int)}
+
+	section := ""
+	lineNumber := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		problem := func(format string, args ...any) {
+			f.problems = append(f.problems, &Error{Source: path, Line: lineNumber, Msg: fmt.Sprintf(format, args...)})
+		}
+
+		// Skip comments and empty lines
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if name, ok := sectionHeader(line); ok {
+			section = name
+			if !isSection(section) {
+				problem("unknown section [%s], known sections are %s", section, strings.Join(sectionNames(), ", "))
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			problem("expected key = value or [section], got %q", line)
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		if section == "" {
+			problem("%s is not in a [section]", key)
+			continue
+		}
+		if !isSection(section) {
+			continue // Already reported at the section header
+		}
+
+		name := section + "." + key
+		s, known := lookupSetting(name)
+		if !known {
+			problem("unknown setting %s in [%s]", key, section)
+			continue
+		}
+		if previous, ok := f.lines[name]; ok {
+			problem("%s is already set on line %d", key, previous)
+			continue
+		}
+		if err := s.check(value); err != nil {
+			problem("invalid %s: %v", key, err)
+			continue
+		}
+
+		f.values[name] = value
+		f.lines[name] = lineNumber
+	}
+
+	return f, scanner.Err()
+}
+
+func sectionHeader(line string) (string, bool) {
+	if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
+		return "", false
+	}
+	return strings.TrimSpace(line[1 : len(line)-1]), true
+}
+
+// envName returns the environment variable overriding a setting, e.g. HINOKI_BACKUP_DIR for backup.dir
+func envName(name string) string {
+	return "HINOKI_" + strings.ToUpper(strings.ReplaceAll(name, ".", "_"))
+}
+
+// applyEnv overrides the settings of the file with the environment variables that are set.
+// Key bindings can only be set in the file
+func (f *file) applyEnv() {
+	f.fromEnv = make(map[string]string)
+
+	for _, name := range settingNames() {
+		variable := envName(name)
+		value, ok := os.LookupEnv(variable)
+		if !ok {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if err := schema[name].check(value); err != nil {
+			f.problems = append(f.problems, &Error{Source: variable, Msg: err.Error()})
+			continue
+		}
+
+		f.values[name] = value
+		f.fromEnv[name] = variable
+	}
+}
+
+// value returns the setting from the environment or the file, or its default
+func (f *file) value(name string) string {
+	if value, ok := f.values[name]; ok {
+		return value
+	}
+	return schema[name].def
+}
+
+// Origin returns where a setting was read from, e.g. "/home/me/.config/hinoki-cli/config.ini:12"
+// or "HINOKI_BACKUP_DIR". Returns an empty string for settings left at their default
+func Origin(name string) string {
+	f, err := current()
+	if err != nil {
+		return ""
+	}
+
+	if variable, ok := f.fromEnv[name]; ok {
+		return variable
+	}
+	if line, ok := f.lines[name]; ok {
+		return fmt.Sprintf("%s:%d", f.path, line)
+	}
+	return ""
+}
+
+// Get returns the value of a setting as it is used, e.g. Get("backup.keep_daily")
+func Get(name string) (string, error) {
+	if _, ok := lookupSetting(name); !ok {
+		return "", fmt.Errorf("unknown setting %s", name)
+	}
+
+	f, err := current()
+	if err != nil {
+		return "", err
+	}
+
+	return f.value(name), nil
+}
+
+// Setting is the value of a setting as it is used
+type Setting struct {
+	Name  string
+	Value string
+}
+
+// All returns the value of every setting of the schema and of every key binding in the config file
+func All() ([]Setting, error) {
+	f, err := current()
+	if err != nil {
+		return nil, err
+	}
+
+	var settings []Setting
+	for _, name := range settingNames() {
+		settings = append(settings, Setting{Name: name, Value: f.value(name)})
+	}
+
+	var bindings []string
+	for name := range f.values {
+		if _, ok := schema[name]; !ok {
+			bindings = append(bindings, name)
+		}
+	}
+	sort.Strings(bindings)
+	for _, name := range bindings {
+		settings = append(settings, Setting{Name: name, Value: f.values[name]})
+	}
+
+	return settings, nil
+}
+
+// Set validates a setting and writes it to the config file, keeping the other lines and comments.
+// The file is created if it doesn't exist yet
+func Set(name, value string) error {
+	s, ok := lookupSetting(name)
+	if !ok {
+		return fmt.Errorf("unknown setting %s", name)
+	}
+
+	value = strings.TrimSpace(value)
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("invalid %s: values can't span lines", name)
+	}
+	if err := s.check(value); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var lines []string
+	if len(content) > 0 {
+		lines = strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	} else {
+		lines = []string{fileHeader}
+	}
+
+	section, key := splitName(name)
+	lines = setLine(lines, section, key, value)
+
+	if err := writeFile(path, lines); err != nil {
+		return err
+	}
+
+	mu.Lock()
+	loaded = nil
+	mu.Unlock()
+
+	return nil
+}
+
+const fileHeader = "# Hinoki Planner settings, see the README for all of them"
+
+// setLine sets key in section to value: the line of the key is replaced if the section has one,
+// otherwise the key is added at the end of the section, or in a new section at the end
+func setLine(lines []string, section, key, value string) []string {
+	newLine := key + " = " + value
+
+	current := ""
+	sectionEnd := -1 // Index after the last setting of the section
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if name, ok := sectionHeader(trimmed); ok {
+			current = name
+			if current == section {
+				sectionEnd = i + 1
+			}
+			continue
+		}
+		if current != section || trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+
+		if k, _, ok := strings.Cut(trimmed, "="); ok && strings.TrimSpace(k) == key {
+			lines[i] = newLine
+			return lines
+		}
+		sectionEnd = i + 1
+	}
+
+	if sectionEnd < 0 {
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		return append(lines, "["+section+"]", newLine)
+	}
+
+	lines = append(lines[:sectionEnd], append([]string{newLine}, lines[sectionEnd:]...)...)
+	return lines
+}
+
+// writeFile replaces the config file, so that it is never left half written
+func writeFile(path string, lines []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
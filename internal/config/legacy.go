@@ -0,0 +1,94 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// legacyNames maps the settings of ~/.hinoki.rc to the settings of the config file
+var legacyNames = map[string]string{
+	"backup_dir":             "backup.dir",
+	"backup_on_startup":      "backup.on_startup",
+	"backup_on_exit":         "backup.on_exit",
+	"backup_interval_days":   "backup.interval_days",
+	"backup_keep_daily":      "backup.keep_daily",
+	"backup_keep_weekly":     "backup.keep_weekly",
+	"backup_keep_monthly":    "backup.keep_monthly",
+	"progress_weighted":      "progress.weighted",
+	"auto_complete_parents":  "progress.auto_complete_parents",
+	"archive_retention_days": "archive.retention_days",
+	"keyboard_layouts":       "keys.layouts",
+}
+
+// MigrateLegacy copies the settings of ~/.hinoki.rc into a new config file, if there is
+// no config file yet. The old file is left in place but no longer read.
+// Returns the path of the migrated file, or an empty string if nothing was migrated
+func MigrateLegacy() (string, error) {
+	path, err := Path()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return "", nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", nil
+	}
+
+	legacyPath := filepath.Join(homeDir, ".hinoki.rc")
+	legacy, err := os.Open(legacyPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", legacyPath, err)
+	}
+	defer legacy.Close()
+
+	lines := []string{fileHeader, "# Moved from " + legacyPath}
+
+	scanner := bufio.NewScanner(legacy)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		oldName, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		oldName, value = strings.TrimSpace(oldName), strings.TrimSpace(value)
+
+		name, ok := legacyNames[oldName]
+		if !ok && strings.HasPrefix(oldName, "keys.") {
+			name, ok = oldName, true
+		}
+		if !ok {
+			// Settings the planner never read are kept as comments
+			lines = append(lines, "# "+line)
+			continue
+		}
+
+		section, key := splitName(name)
+		lines = setLine(lines, section, key, value)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", legacyPath, err)
+	}
+
+	if err := writeFile(path, lines); err != nil {
+		return "", err
+	}
+
+	mu.Lock()
+	loaded = nil
+	mu.Unlock()
+
+	return path, nil
+}
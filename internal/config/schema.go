@@ -0,0 +1,206 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// setting describes a setting of the config file, named by its section and key, e.g. backup.dir
+type setting struct {
+	def   string
+	check func(value string) error
+}
+
+// schemaSettings are the settings of the config file in the order they are documented
+var schemaSettings = []namedSetting{
+	{"database.path", setting{check: anyValue}},
+
+	{"workspace.current", setting{def: "default", check: ValidateWorkspaceName}},
+
+	{"backup.dir", setting{def: "~/Documents/hinoki-backups", check: anyValue}},
+	{"backup.on_startup", setting{def: "false", check: boolValue}},
+	{"backup.on_exit", setting{def: "false", check: boolValue}},
+	{"backup.interval_days", setting{def: "0", check: countValue}},
+	{"backup.keep_daily", setting{def: "0", check: countValue}},
+	{"backup.keep_weekly", setting{def: "0", check: countValue}},
+	{"backup.keep_monthly", setting{def: "0", check: countValue}},
+
+	{"progress.weighted", setting{def: "false", check: boolValue}},
+	{"progress.auto_complete_parents", setting{def: "false", check: boolValue}},
+
+	{"archive.retention_days", setting{def: "0", check: countValue}},
+
+	{"rollforward.count_postponed", setting{def: "true", check: boolValue}},
+
+	{"ui.theme", setting{def: "auto", check: nameValue}},
+
+	{"calendar.week_start", setting{def: "monday", check: oneOf(weekdayNames...)}},
+	{"calendar.locale", setting{def: "en", check: oneOf("en", "ja")}},
+
+	{"startup.timeframe", setting{def: "day", check: oneOf("day", "week", "month", "quarter", "year", "life")}},
+	{"startup.splash", setting{def: "true", check: boolValue}},
+	{"startup.screen", setting{def: "timeframe", check: oneOf("timeframe", "dashboard")}},
+
+	{"keys.layouts", setting{def: strings.Join(DefaultKeyboardLayouts, " "), check: anyValue}},
+}
+
+type namedSetting struct {
+	name string
+	setting
+}
+
+// schema are the settings of the config file by name
+var schema = func() map[string]setting {
+	settings := make(map[string]setting, len(schemaSettings))
+	for _, s := range schemaSettings {
+		settings[s.name] = s.setting
+	}
+	return settings
+}()
+
+var weekdayNames = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// lookupSetting returns the description of a setting. Key bindings such as keys.timeframe.next_period
+// accept any keys here, unknown actions are reported by the keymap package
+func lookupSetting(name string) (setting, bool) {
+	if s, ok := schema[name]; ok {
+		return s, true
+	}
+
+	if action, ok := strings.CutPrefix(name, "keys."); ok {
+		scope, actionName, ok := strings.Cut(action, ".")
+		if ok && scope != "" && actionName != "" && !strings.Contains(actionName, ".") {
+			return setting{check: anyValue}, true
+		}
+	}
+
+	if section, role := splitName(name); isPaletteSection(section) {
+		switch {
+		case role == "base":
+			return setting{check: nameValue}, true
+		case slices.Contains(PaletteRoles, role):
+			return setting{check: colorValue}, true
+		}
+	}
+
+	return setting{}, false
+}
+
+// PaletteRoles are the colors a [palette.<name>] section can set, along with the theme it is based on
+var PaletteRoles = []string{"primary", "secondary", "muted", "disabled", "selected", "overdue", "done", "tag"}
+
+// splitName splits the name of a setting into its section and key. Palette sections are
+// named palette.<name>, key bindings have dots in their key
+func splitName(name string) (section, key string) {
+	if strings.HasPrefix(name, "palette.") {
+		if i := strings.LastIndex(name, "."); i > len("palette.") {
+			return name[:i], name[i+1:]
+		}
+	}
+
+	section, key, _ = strings.Cut(name, ".")
+	return section, key
+}
+
+func isPaletteSection(section string) bool {
+	name, ok := strings.CutPrefix(section, "palette.")
+	return ok && nameValue(name) == nil
+}
+
+// sectionNames returns the sections of the config file in the order they are documented.
+// Palettes are documented after the ui section that picks them, key bindings last
+func sectionNames() []string {
+	var names []string
+	for _, s := range schemaSettings {
+		section, _ := splitName(s.name)
+		if section == "keys" || slices.Contains(names, section) {
+			continue
+		}
+
+		names = append(names, section)
+		if section == "ui" {
+			names = append(names, "palette.<name>")
+		}
+	}
+
+	return append(names, "keys")
+}
+
+func isSection(name string) bool {
+	return slices.Contains(sectionNames(), name) || isPaletteSection(name)
+}
+
+// settingNames returns the names of all settings of the schema, sorted
+func settingNames() []string {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateWorkspaceName checks that a workspace name can be used as a file name, e.g. "work"
+func ValidateWorkspaceName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("%q is not a workspace name of letters, digits, - and _", name)
+	}
+	return nil
+}
+
+var (
+	namePattern  = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,39}$`)
+	colorPattern = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+)
+
+// nameValue checks names of themes and palettes
+func nameValue(value string) error {
+	if !namePattern.MatchString(value) {
+		return fmt.Errorf("%q is not a name of letters, digits, - and _", value)
+	}
+	return nil
+}
+
+// colorValue checks colors written as #rgb, #rrggbb or an ANSI color number from 0 to 255
+func colorValue(value string) error {
+	if colorPattern.MatchString(value) {
+		return nil
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return nil
+	}
+	return fmt.Errorf("%q is not a color like #ff8800 or an ANSI color number from 0 to 255", value)
+}
+
+func anyValue(string) error {
+	return nil
+}
+
+func boolValue(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("%q is not true or false", value)
+	}
+	return nil
+}
+
+func countValue(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n < 0 {
+		return fmt.Errorf("%q is not a non-negative number", value)
+	}
+	return nil
+}
+
+func oneOf(values ...string) func(string) error {
+	return func(value string) error {
+		for _, v := range values {
+			if strings.EqualFold(v, value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of %s", value, strings.Join(values, ", "))
+	}
+}
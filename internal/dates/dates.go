@@ -87,26 +87,48 @@ func TimeframeDateString(t time.Time) string {
 	return t.Format("2006-01-02")
 }
 
+var (
+	weekStart = time.Monday
+	locale    = "en"
+)
+
+// SetWeekStart sets the first day of week periods, Monday unless configured otherwise
+func SetWeekStart(day time.Weekday) {
+	weekStart = day
+}
+
+// SetLocale sets the language of weekday names, "en" or "ja"
+func SetLocale(l string) {
+	locale = l
+}
+
 func StartOfWeek(date time.Time) time.Time {
-	// Calculate the number of days since Monday
-	offset := int(date.Weekday()) - int(time.Monday)
-	if offset < 0 {
-		offset += 7 // Adjust for Sunday being the 0th day
-	}
+	// Calculate the number of days since the first day of the week
+	offset := (int(date.Weekday()) - int(weekStart) + 7) % 7
 
 	// Subtract offset days from the given date
-	return date.AddDate(0, 0, -offset).Truncate(24 * time.Hour)
+	return DateWithoutTime(date.AddDate(0, 0, -offset))
 }
 
 func EndOfWeek(date time.Time) time.Time {
-	// Calculate the number of days since Monday
-	offset := int(time.Sunday) - int(date.Weekday())
-	if offset < 0 {
-		offset += 7 // Adjust for Sunday being the 0th day
-	}
+	// Calculate the number of days until the last day of the week
+	offset := (int(weekStart) + 6 - int(date.Weekday())) % 7
 
-	// Subtract offset days from the given date
-	return date.AddDate(0, 0, offset).Truncate(24 * time.Hour)
+	// Add offset days to the given date
+	return DateWithoutTime(date.AddDate(0, 0, offset))
+}
+
+// MonthGrid returns the days of a calendar page for the month of date: whole weeks from the
+// week of the 1st to the week of the last day of the month
+func MonthGrid(date time.Time) []time.Time {
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	last := EndOfWeek(first.AddDate(0, 1, -1))
+
+	var days []time.Time
+	for day := StartOfWeek(first); !day.After(last); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
 }
 
 func DateWithoutTime(date time.Time) time.Time {
@@ -441,10 +463,18 @@ func englishDayWeek(date time.Time) string {
 	return ""
 }
 
+// DayOfWeek returns the short weekday name in the configured locale
+func DayOfWeek(date time.Time) string {
+	if locale == "ja" {
+		return japaneseDayWeek(date)
+	}
+	return englishDayWeek(date)
+}
+
 func DateString(t time.Time, timeslice goal.Timeframe) string {
 	switch timeslice {
 	case goal.Day:
-		return fmt.Sprintf("%s (%s)", t.Format("2 January 2006"), englishDayWeek(t))
+		return fmt.Sprintf("%s (%s)", t.Format("2 January 2006"), DayOfWeek(t))
 	case goal.Week:
 		_, week := t.ISOWeek()
 		return fmt.Sprintf("%s – %s %s (%d)", StartOfWeek(t).Format("02"), EndOfWeek(t).Format("02"), t.Format("January 2006"), week)
@@ -461,6 +491,31 @@ func DateString(t time.Time, timeslice goal.Timeframe) string {
 	return t.Format("02 January 2006")
 }
 
+// StartOfPeriod returns midnight of the first day of the timeframe period containing t
+func StartOfPeriod(t time.Time, timeframe goal.Timeframe) time.Time {
+	day := DateWithoutTime(t)
+
+	switch timeframe {
+	case goal.Week:
+		return StartOfWeek(day)
+	case goal.Month:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	case goal.Quarter:
+		month := (day.Month()-1)/3*3 + 1
+		return time.Date(day.Year(), month, 1, 0, 0, 0, 0, day.Location())
+	case goal.Year:
+		return time.Date(day.Year(), 1, 1, 0, 0, 0, 0, day.Location())
+	}
+
+	return day
+}
+
+// PeriodString identifies the timeframe period containing t by its first day, e.g. "2024-03-01"
+// for March 2024
+func PeriodString(t time.Time, timeframe goal.Timeframe) string {
+	return TimeframeDateString(StartOfPeriod(t, timeframe))
+}
+
 func ChangePeriod(t time.Time, timeframe goal.Timeframe, by int) time.Time {
 	switch timeframe {
 	case goal.Day:
@@ -477,14 +532,34 @@ func ChangePeriod(t time.Time, timeframe goal.Timeframe, by int) time.Time {
 	return t
 }
 
+// RollForward returns the date a goal of a period is moved to when it is carried into the next
+// period, or into the current period at now if the next one has passed too, on its first day
+func RollForward(date time.Time, timeframe goal.Timeframe, now time.Time) time.Time {
+	next := ChangePeriod(StartOfPeriod(date, timeframe), timeframe, 1)
+	if current := StartOfPeriod(now, timeframe); next.Before(current) {
+		next = current
+	}
+	return GoalDate(next)
+}
+
+// GoalDate returns the date stored for a goal of the period starting on day. Noon keeps SQLite's
+// DATE(), which converts to UTC, on the same day for every time zone offset
+func GoalDate(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, day.Location())
+}
+
 // IsOverdue checks if a goal is overdue based on its date and timeframe
 // A goal is overdue if it has a date and timeframe, and the period has passed
 func IsOverdue(date *time.Time, timeframe *goal.Timeframe) bool {
+	return IsOverdueAt(date, timeframe, time.Now())
+}
+
+// IsOverdueAt checks if the period of a goal had passed at the time now
+func IsOverdueAt(date *time.Time, timeframe *goal.Timeframe, now time.Time) bool {
 	if date == nil || timeframe == nil {
 		return false
 	}
 
-	now := time.Now()
 	today := DateWithoutTime(now)
 
 	switch *timeframe {
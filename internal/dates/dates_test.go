@@ -210,3 +210,99 @@ func TestTimeframeDateString(t *testing.T) {
 		t.Errorf("TimeframeDateString(%v) = %s; want %s", utcDate, resultUTC, expected)
 	}
 }
+
+func TestStartOfPeriod(t *testing.T) {
+	layout := "2006-01-02"
+	date := time.Date(2024, 11, 24, 15, 30, 0, 0, time.Local) // Sun
+
+	cases := map[goal.Timeframe]string{
+		goal.Day:     "2024-11-24",
+		goal.Week:    "2024-11-18",
+		goal.Month:   "2024-11-01",
+		goal.Quarter: "2024-10-01",
+		goal.Year:    "2024-01-01",
+	}
+
+	for timeframe, expected := range cases {
+		result := StartOfPeriod(date, timeframe)
+		if result.Format(layout) != expected || result.Hour() != 0 {
+			t.Errorf("%s: res %s; want %s", timeframe, result, expected)
+		}
+	}
+}
+
+func TestWeekStart_Sunday(t *testing.T) {
+	SetWeekStart(time.Sunday)
+	defer SetWeekStart(time.Monday)
+
+	layout := "2006-01-02"
+	date := time.Date(2024, 11, 24, 15, 30, 0, 0, time.UTC) // Sun
+
+	if result := StartOfPeriod(date, goal.Week).Format(layout); result != "2024-11-24" {
+		t.Errorf("StartOfPeriod = %s; want 2024-11-24", result)
+	}
+	if result := StartOfWeek(date).Format(layout); result != "2024-11-24" {
+		t.Errorf("StartOfWeek = %s; want 2024-11-24", result)
+	}
+	if result := EndOfWeek(date).Format(layout); result != "2024-11-30" {
+		t.Errorf("EndOfWeek = %s; want 2024-11-30", result)
+	}
+}
+
+func TestStartOfWeek_LocalMidnight(t *testing.T) {
+	layout := "2006-01-02 15:04"
+
+	for _, offset := range []int{9, -5} {
+		zone := time.FixedZone("test", offset*60*60)
+		date := time.Date(2024, 11, 27, 15, 30, 0, 0, zone) // Wednesday
+
+		if start := StartOfWeek(date); start.Format(layout) != "2024-11-25 00:00" {
+			t.Errorf("StartOfWeek at UTC%+d = %s; want 2024-11-25 00:00", offset, start.Format(layout))
+		}
+		if end := EndOfWeek(date); end.Format(layout) != "2024-12-01 00:00" {
+			t.Errorf("EndOfWeek at UTC%+d = %s; want 2024-12-01 00:00", offset, end.Format(layout))
+		}
+	}
+}
+
+func TestMonthGrid(t *testing.T) {
+	layout := "2006-01-02"
+	date := time.Date(2024, 11, 15, 15, 30, 0, 0, time.UTC) // Nov 1 is a Friday, Nov 30 a Saturday
+
+	days := MonthGrid(date)
+	if len(days) != 35 || days[0].Format(layout) != "2024-10-28" || days[34].Format(layout) != "2024-12-01" {
+		t.Errorf("MonthGrid = %d days from %s to %s; want 35 from 2024-10-28 to 2024-12-01", len(days), days[0].Format(layout), days[len(days)-1].Format(layout))
+	}
+
+	SetWeekStart(time.Sunday)
+	defer SetWeekStart(time.Monday)
+
+	days = MonthGrid(date)
+	if len(days) != 35 || days[0].Format(layout) != "2024-10-27" || days[34].Format(layout) != "2024-11-30" {
+		t.Errorf("MonthGrid = %d days from %s to %s; want 35 from 2024-10-27 to 2024-11-30", len(days), days[0].Format(layout), days[len(days)-1].Format(layout))
+	}
+}
+
+func TestRollForward(t *testing.T) {
+	layout := "2006-01-02 15:04"
+	now := time.Date(2024, 11, 20, 9, 0, 0, 0, time.UTC) // Wednesday
+
+	tests := []struct {
+		date      time.Time
+		timeframe goal.Timeframe
+		want      string
+	}{
+		{time.Date(2024, 11, 19, 18, 0, 0, 0, time.UTC), goal.Day, "2024-11-20 12:00"},
+		{time.Date(2024, 11, 20, 18, 0, 0, 0, time.UTC), goal.Day, "2024-11-21 12:00"},
+		{time.Date(2024, 11, 14, 8, 0, 0, 0, time.UTC), goal.Week, "2024-11-18 12:00"},
+		{time.Date(2024, 10, 31, 8, 0, 0, 0, time.UTC), goal.Month, "2024-11-01 12:00"},
+		{time.Date(2024, 1, 31, 8, 0, 0, 0, time.UTC), goal.Month, "2024-11-01 12:00"}, // Months late goals go to the current month
+		{time.Date(2024, 8, 15, 8, 0, 0, 0, time.UTC), goal.Quarter, "2024-10-01 12:00"},
+	}
+
+	for _, tt := range tests {
+		if got := RollForward(tt.date, tt.timeframe, now).Format(layout); got != tt.want {
+			t.Errorf("RollForward(%s, %s) = %s; want %s", tt.date.Format(layout), tt.timeframe, got, tt.want)
+		}
+	}
+}
@@ -1,24 +1,22 @@
 package db
 
 import (
+	"database/sql"
 	"fmt"
 	"hinoki-cli/internal/config"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
-// CreateBackup creates a backup of the database file in the configured backup directory
+// CreateBackup creates a backup of the database in the configured backup directory
+// The backup is written with VACUUM INTO, so it is a consistent snapshot even while the database is in use
 // Returns the full path to the created backup file
 func CreateBackup() (string, error) {
-	dbPath, err := getDBPath()
-	if err != nil {
-		return "", fmt.Errorf("failed to get DB path: %w", err)
-	}
-
-	// Get backup directory from config (creates config file if it doesn't exist)
-	backupDir, err := config.GetBackupDir()
+	backupDir, err := backupDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get backup directory: %w", err)
 	}
@@ -30,27 +28,287 @@ func CreateBackup() (string, error) {
 
 	// Create backup filename with timestamp
 	timestamp := time.Now().Format("20060102_150405")
-	backupName := fmt.Sprintf("hinoki_backup_%s.db", timestamp)
-	backupPath := filepath.Join(backupDir, backupName)
+	backupPath := filepath.Join(backupDir, backupPrefix+timestamp+backupSuffix)
+
+	// VACUUM INTO refuses to overwrite, so don't clash with a backup made in the same second
+	for i := 1; fileExists(backupPath); i++ {
+		backupPath = filepath.Join(backupDir, fmt.Sprintf("%s%s_%d%s", backupPrefix, timestamp, i, backupSuffix))
+	}
+
+	if _, err := ExecQuery("VACUUM INTO ?", backupPath); err != nil {
+		os.Remove(backupPath) // Clean up on error
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	return backupPath, nil
+}
+
+type BackupTrigger int
+
+const (
+	BackupOnStartup BackupTrigger = iota
+	BackupOnExit
+)
+
+// CreateScheduledBackup creates a backup if the backup settings ask for one at this trigger,
+// then prunes old backups according to the retention policy
+// Returns the path of the created backup, or an empty string if none was due
+func CreateScheduledBackup(trigger BackupTrigger) (string, error) {
+	settings, err := config.GetBackupSettings()
+	if err != nil {
+		return "", err
+	}
+
+	due := false
+	switch trigger {
+	case BackupOnStartup:
+		due = settings.OnStartup
+		if !due && settings.IntervalDays > 0 {
+			due, err = backupIntervalElapsed(settings.IntervalDays)
+			if err != nil {
+				return "", err
+			}
+		}
+	case BackupOnExit:
+		due = settings.OnExit
+	}
+
+	if !due {
+		return "", nil
+	}
+
+	backupPath, err := CreateBackup()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := PruneBackups(); err != nil {
+		return backupPath, err
+	}
+
+	return backupPath, nil
+}
+
+// PruneBackups deletes backups that are not kept by the retention policy
+// Returns the names of the deleted backups
+func PruneBackups() ([]string, error) {
+	settings, err := config.GetBackupSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	if !settings.HasRetention() {
+		return nil, nil
+	}
+
+	backups, err := ListBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []string
+	for _, backup := range backupsToPrune(backups, settings) {
+		if err := os.Remove(backup.Path); err != nil {
+			return pruned, fmt.Errorf("failed to remove %s: %w", backup.Name, err)
+		}
+		pruned = append(pruned, backup.Name)
+	}
+
+	return pruned, nil
+}
+
+// backupIntervalElapsed reports whether the newest backup is at least days old, or there is none
+func backupIntervalElapsed(days int) (bool, error) {
+	backups, err := ListBackups()
+	if err != nil {
+		return false, err
+	}
+
+	if len(backups) == 0 {
+		return true, nil
+	}
+
+	return !time.Now().Before(backups[0].CreatedAt.AddDate(0, 0, days)), nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+const (
+	backupPrefix = "hinoki_backup_"
+	backupSuffix = ".db"
+)
 
-	// Copy database file
-	source, err := os.Open(dbPath)
+// BackupInfo describes a backup file in the backup directory
+type BackupInfo struct {
+	Path      string
+	Name      string
+	Size      int64
+	CreatedAt time.Time
+	// GoalCount is the number of goals in the backup, or -1 if the backup could not be read
+	GoalCount int
+}
+
+// ListBackups returns the backups in the configured backup directory, newest first
+func ListBackups() ([]BackupInfo, error) {
+	backupDir, err := backupDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to open source DB: %w", err)
+		return nil, fmt.Errorf("failed to get backup directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(backupDir)
+	if os.IsNotExist(err) {
+		return []BackupInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	backups := []BackupInfo{}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, backupSuffix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		path := filepath.Join(backupDir, name)
+		backup := BackupInfo{
+			Path:      path,
+			Name:      name,
+			Size:      info.Size(),
+			CreatedAt: backupTime(name, info.ModTime()),
+			GoalCount: -1,
+		}
+
+		if count, err := countBackupGoals(path); err == nil {
+			backup.GoalCount = count
+		}
+
+		backups = append(backups, backup)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+
+	return backups, nil
+}
+
+// VerifyBackup opens the backup read-only and runs SQLite's integrity check on it
+func VerifyBackup(path string) error {
+	backup, err := openReadOnly(path)
+	if err != nil {
+		return err
+	}
+	defer backup.Close()
+
+	var result string
+	if err := backup.QueryRow("PRAGMA integrity_check").Scan(&result); err != nil {
+		return fmt.Errorf("integrity check failed: %w", err)
+	}
+
+	if result != "ok" {
+		return fmt.Errorf("integrity check failed: %s", result)
+	}
+
+	if _, err := countGoals(backup); err != nil {
+		return fmt.Errorf("backup has no readable goals table: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreBackup replaces the current database with the given backup
+// The backup is verified and the current database is backed up first
+// Returns the path of the backup made of the current database
+func RestoreBackup(path string) (string, error) {
+	if err := VerifyBackup(path); err != nil {
+		return "", err
+	}
+
+	safetyBackupPath, err := CreateBackup()
+	if err != nil {
+		return "", fmt.Errorf("failed to back up current database: %w", err)
+	}
+
+	dbPath := instancePath
+
+	err = reopenDB(dbPath, func() error {
+		return replaceFile(dbPath, path)
+	})
+	if err != nil {
+		return safetyBackupPath, fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	return safetyBackupPath, nil
+}
+
+// replaceFile atomically replaces dst with a copy of src
+func replaceFile(dst string, src string) error {
+	source, err := os.Open(src)
+	if err != nil {
+		return err
 	}
 	defer source.Close()
 
-	dest, err := os.Create(backupPath)
+	tmpPath := dst + ".restore"
+	tmp, err := os.Create(tmpPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to create backup file: %w", err)
+		return err
+	}
+
+	if _, err := io.Copy(tmp, source); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
 	}
-	defer dest.Close()
 
-	_, err = io.Copy(dest, source)
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, dst)
+}
+
+func openReadOnly(path string) (*sql.DB, error) {
+	conn, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
 	if err != nil {
-		os.Remove(backupPath) // Clean up on error
-		return "", fmt.Errorf("failed to copy DB: %w", err)
+		return nil, fmt.Errorf("failed to open backup: %w", err)
 	}
+	return conn, nil
+}
 
-	return backupPath, nil
+func countBackupGoals(path string) (int, error) {
+	backup, err := openReadOnly(path)
+	if err != nil {
+		return 0, err
+	}
+	defer backup.Close()
+
+	return countGoals(backup)
+}
+
+func countGoals(conn *sql.DB) (int, error) {
+	var count int
+	err := conn.QueryRow("SELECT COUNT(*) FROM goals WHERE is_archived IS NOT true").Scan(&count)
+	return count, err
+}
+
+// backupTime reads the creation time from the backup file name, falling back to the modification time
+func backupTime(name string, modTime time.Time) time.Time {
+	timestamp := strings.TrimSuffix(strings.TrimPrefix(name, backupPrefix), backupSuffix)
+	t, err := time.ParseInLocation("20060102_150405", timestamp, time.Local)
+	if err != nil {
+		return modTime
+	}
+	return t
 }
@@ -15,28 +15,28 @@ const (
 )
 
 var (
-	instance *sql.DB
-	once     sync.Once
-	mu       sync.Mutex
+	instance     *sql.DB
+	instancePath string
+	once         sync.Once
+	mu           sync.Mutex
 )
 
-// •	macOS: ~/Library/Application Support/hinoki-planner/local.db
-// •	Linux: ~/.config/hinoki-planner/local.db
-// •	Windows: %AppData%\hinoki-planner\local.db
+// getDBPath returns the database to open: the path set with --db or HINOKI_DB, otherwise
+// the database of the workspace. The directory of the database is created if it doesn't exist
 func getDBPath() (string, error) {
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		return "", err
+	path := pathOverride
+	if path == "" {
+		var err error
+		if path, err = WorkspacePath(currentWorkspace()); err != nil {
+			return "", err
+		}
 	}
 
-	hinokiCliDir := filepath.Join(configDir, "hinoki-cli")
-
-	if _, err := os.Stat(hinokiCliDir); os.IsNotExist(err) {
-		err := os.Mkdir(hinokiCliDir, 0700)
-		return "", err
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create database directory: %w", err)
 	}
 
-	return filepath.Join(configDir, "hinoki-cli", "hinoki.db"), nil
+	return path, nil
 }
 
 func InitDB() *sql.DB {
@@ -46,29 +46,86 @@ func InitDB() *sql.DB {
 			panic(err)
 		}
 
-		inst, err := sql.Open("sqlite3", path)
-		instance = inst
-
+		inst, err := openDB(path)
 		if err != nil {
 			panic(err)
 		}
 
-		err = createSchemaVersionTable()
-		if err != nil {
-			panic(err)
-		}
-
-		err = applyMigrations(inst)
-		if err != nil {
-			panic(err)
-		}
+		mu.Lock()
+		instance = inst
+		instancePath = path
+		mu.Unlock()
 	})
 
 	return instance
 }
 
+// IsOpen reports whether InitDB has opened the database, which the planner does while it starts
+func IsOpen() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return instance != nil
+}
+
+// CloseDB closes the database, if it was opened
 func CloseDB() {
-	instance.Close()
+	mu.Lock()
+	defer mu.Unlock()
+	if instance != nil {
+		instance.Close()
+	}
+}
+
+// openDB opens the database at path and migrates it to the latest schema
+func openDB(path string) (*sql.DB, error) {
+	inst, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := createSchemaVersionTable(inst); err != nil {
+		inst.Close()
+		return nil, err
+	}
+
+	if err := applyMigrations(inst); err != nil {
+		inst.Close()
+		return nil, err
+	}
+
+	return inst, nil
+}
+
+// reopenDB closes the current connection, runs prepare while no connection is open
+// and opens the database at path. If that fails, the previous database is reopened
+func reopenDB(path string, prepare func() error) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	previousPath := instancePath
+
+	if err := instance.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+
+	err := prepare()
+	if err == nil {
+		var inst *sql.DB
+		inst, err = openDB(path)
+		if err == nil {
+			instance = inst
+			instancePath = path
+			return nil
+		}
+	}
+
+	inst, reopenErr := openDB(previousPath)
+	if reopenErr != nil {
+		return fmt.Errorf("%w (and failed to reopen previous database: %v)", err, reopenErr)
+	}
+	instance = inst
+
+	return err
 }
 
 func ExecQuery(query string, args ...interface{}) (sql.Result, error) {
@@ -89,8 +146,42 @@ func QueryRowDB(query string, args ...interface{}) *sql.Row {
 	return instance.QueryRow(query, args...)
 }
 
-func createSchemaVersionTable() error {
-	_, err := ExecQuery(`
+// WithTransaction runs fn inside a single transaction, rolling back if it returns an error
+func WithTransaction(fn func(tx *sql.Tx) error) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	tx, err := instance.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SchemaVersion returns the version of the latest applied migration
+func SchemaVersion() (int, error) {
+	var version int
+	err := QueryRowDB("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version)
+	return version, err
+}
+
+// LatestSchemaVersion returns the version this build migrates the database to
+func LatestSchemaVersion() int {
+	latest := 0
+	for version := range migrations {
+		latest = max(latest, version)
+	}
+	return latest
+}
+
+func createSchemaVersionTable(db *sql.DB) error {
+	_, err := db.Exec(`
 		CREATE TABLE IF NOT EXISTS schema_version (
     		version INTEGER PRIMARY KEY,
     		applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
@@ -140,7 +231,7 @@ func applyMigrations(db *sql.DB) error {
 			return fmt.Errorf("failed to update schema_version table: %w", err)
 		}
 
-		fmt.Println("Applied migrationsArr version:", migration.version)
+		fmt.Fprintln(os.Stderr, "Applied migrationsArr version:", migration.version)
 	}
 	return nil
 }
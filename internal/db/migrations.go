@@ -13,10 +13,74 @@ const (
 	)`
 	addArchivedToGoals = `ALTER TABLE goals ADD COLUMN is_archived BOOLEAN;`
 	addParentId        = `ALTER TABLE goals ADD COLUMN parent_id TEXT;`
+	addNotesToGoals    = `ALTER TABLE goals ADD COLUMN notes TEXT;`
+	createTagsTables   = `
+	CREATE TABLE IF NOT EXISTS tags (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE
+	);
+	CREATE TABLE IF NOT EXISTS goal_tags (
+		goal_id TEXT NOT NULL,
+		tag_id INTEGER NOT NULL REFERENCES tags(id),
+		PRIMARY KEY (goal_id, tag_id)
+	);`
+	createSeriesTable = `
+	CREATE TABLE IF NOT EXISTS series (
+		id TEXT PRIMARY KEY,
+		title TEXT NOT NULL,
+		tags TEXT,
+		parent_id TEXT,
+		rule TEXT NOT NULL,
+		start_period TEXT NOT NULL,
+		stopped_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+	ALTER TABLE goals ADD COLUMN series_id TEXT;
+	ALTER TABLE goals ADD COLUMN series_period TEXT;
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_goals_series_period ON goals (series_id, series_period);`
+	createGoalsIndexes = `
+	CREATE INDEX IF NOT EXISTS idx_goals_parent_id ON goals (parent_id);
+	CREATE INDEX IF NOT EXISTS idx_goals_timeframe_date ON goals (timeframe, DATE(date));`
+	addArchivedAtToGoals = `
+	ALTER TABLE goals ADD COLUMN archived_at DATETIME;
+	UPDATE goals SET archived_at = CURRENT_TIMESTAMP WHERE is_archived = 1;`
+	createGoalEventsTable = `
+	CREATE TABLE IF NOT EXISTS goal_events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		goal_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		old_value TEXT,
+		new_value TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_goal_events_goal_id ON goal_events (goal_id);
+	CREATE TABLE IF NOT EXISTS keep_updated_at (flag INTEGER);
+	CREATE TRIGGER IF NOT EXISTS goals_updated_at AFTER UPDATE ON goals
+	FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at AND NOT EXISTS (SELECT 1 FROM keep_updated_at)
+	BEGIN
+		UPDATE goals SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+	END;`
+	createPeriodNotesTable = `
+	CREATE TABLE IF NOT EXISTS period_notes (
+		timeframe TEXT NOT NULL,
+		period TEXT NOT NULL,
+		text TEXT NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (timeframe, period)
+	);`
+	addPostponedCountToGoals = `ALTER TABLE goals ADD COLUMN postponed_count INTEGER NOT NULL DEFAULT 0;`
 )
 
 var migrations = map[int]string{
-	1: createGoalsTable,
-	2: addArchivedToGoals,
-	3: addParentId,
+	1:  createGoalsTable,
+	2:  addArchivedToGoals,
+	3:  addParentId,
+	4:  addNotesToGoals,
+	5:  createTagsTables,
+	6:  createSeriesTable,
+	7:  createGoalsIndexes,
+	8:  addArchivedAtToGoals,
+	9:  createGoalEventsTable,
+	10: createPeriodNotesTable,
+	11: addPostponedCountToGoals,
 }
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"fmt"
+	"hinoki-cli/internal/config"
+	"sort"
+	"time"
+)
+
+// backupsToPrune applies a daily/weekly/monthly retention policy to the backups
+// For each of the most recent KeepDaily days, KeepWeekly weeks and KeepMonthly months
+// that have backups, the newest backup of that period is kept. The newest backup overall
+// is always kept. Everything else is returned for deletion
+func backupsToPrune(backups []BackupInfo, settings config.BackupSettings) []BackupInfo {
+	if !settings.HasRetention() || len(backups) == 0 {
+		return nil
+	}
+
+	sorted := make([]BackupInfo, len(backups))
+	copy(sorted, backups)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	keep := map[string]bool{sorted[0].Path: true}
+
+	keepNewestPerPeriod := func(limit int, period func(time.Time) string) {
+		seen := make(map[string]bool)
+		for _, backup := range sorted {
+			if len(seen) >= limit {
+				return
+			}
+			key := period(backup.CreatedAt)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			keep[backup.Path] = true
+		}
+	}
+
+	keepNewestPerPeriod(settings.KeepDaily, func(t time.Time) string {
+		return t.Format("2006-01-02")
+	})
+	keepNewestPerPeriod(settings.KeepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-%02d", year, week)
+	})
+	keepNewestPerPeriod(settings.KeepMonthly, func(t time.Time) string {
+		return t.Format("2006-01")
+	})
+
+	var prune []BackupInfo
+	for _, backup := range sorted {
+		if !keep[backup.Path] {
+			prune = append(prune, backup)
+		}
+	}
+
+	return prune
+}
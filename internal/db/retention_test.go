@@ -0,0 +1,67 @@
+package db
+
+import (
+	"fmt"
+	"hinoki-cli/internal/config"
+	"testing"
+	"time"
+)
+
+func backupsEveryDay(start time.Time, days int) []BackupInfo {
+	var backups []BackupInfo
+	for i := 0; i < days; i++ {
+		createdAt := start.AddDate(0, 0, -i)
+		backups = append(backups, BackupInfo{Path: fmt.Sprintf("backup-%d", i), CreatedAt: createdAt})
+	}
+	return backups
+}
+
+func TestBackupsToPrune_NoRetention(t *testing.T) {
+	backups := backupsEveryDay(time.Date(2024, 11, 21, 12, 0, 0, 0, time.UTC), 10)
+
+	if prune := backupsToPrune(backups, config.BackupSettings{}); len(prune) != 0 {
+		t.Errorf("pruned %d backups without a retention policy; want 0", len(prune))
+	}
+}
+
+func TestBackupsToPrune_KeepDaily(t *testing.T) {
+	backups := backupsEveryDay(time.Date(2024, 11, 21, 12, 0, 0, 0, time.UTC), 10)
+
+	prune := backupsToPrune(backups, config.BackupSettings{KeepDaily: 7})
+	if len(prune) != 3 {
+		t.Fatalf("pruned %d backups; want 3", len(prune))
+	}
+
+	for _, backup := range prune {
+		if backup.CreatedAt.After(time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("pruned backup from %s; want only backups older than a week", backup.CreatedAt)
+		}
+	}
+}
+
+func TestBackupsToPrune_KeepsNewestOfTheDay(t *testing.T) {
+	day := time.Date(2024, 11, 21, 0, 0, 0, 0, time.UTC)
+	backups := []BackupInfo{
+		{Path: "morning", CreatedAt: day.Add(8 * time.Hour)},
+		{Path: "evening", CreatedAt: day.Add(20 * time.Hour)},
+	}
+
+	prune := backupsToPrune(backups, config.BackupSettings{KeepDaily: 1})
+	if len(prune) != 1 || prune[0].Path != "morning" {
+		t.Errorf("pruned %v; want the morning backup", prune)
+	}
+}
+
+func TestBackupsToPrune_DailyWeeklyMonthly(t *testing.T) {
+	// 120 daily backups, ending on Thursday 21 November 2024
+	backups := backupsEveryDay(time.Date(2024, 11, 21, 12, 0, 0, 0, time.UTC), 120)
+
+	prune := backupsToPrune(backups, config.BackupSettings{KeepDaily: 7, KeepWeekly: 4, KeepMonthly: 12})
+
+	kept := len(backups) - len(prune)
+	// 7 days (15-21 November), the Sundays of the two weeks before those
+	// and the last day of July, August, September and October
+	if kept != 13 {
+		t.Errorf("kept %d backups; want 13", kept)
+	}
+}
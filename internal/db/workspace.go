@@ -0,0 +1,150 @@
+package db
+
+import (
+	"fmt"
+	"hinoki-cli/internal/config"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultWorkspace is the workspace of the database at the default location, or at database.path
+const DefaultWorkspace = "default"
+
+var (
+	pathOverride string // Database set with --db or HINOKI_DB, replaces the workspace database
+	workspace    string // Workspace set with --workspace or switched to, empty to use the config
+)
+
+// SetPath makes InitDB open the database at path instead of the database of a workspace
+func SetPath(path string) {
+	pathOverride = path
+}
+
+// SetWorkspace makes InitDB open the database of the named workspace, which is created
+// if it doesn't exist yet
+func SetWorkspace(name string) error {
+	if err := config.ValidateWorkspaceName(name); err != nil {
+		return err
+	}
+	workspace = name
+	return nil
+}
+
+// Workspace returns the name of the open workspace, or the file name of a database set by path
+func Workspace() string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if pathOverride != "" {
+		return filepath.Base(pathOverride)
+	}
+	return currentWorkspace()
+}
+
+func currentWorkspace() string {
+	if workspace != "" {
+		return workspace
+	}
+	if name, err := config.Get("workspace.current"); err == nil && name != "" {
+		return name
+	}
+	return DefaultWorkspace
+}
+
+// WorkspacePath returns the database of a workspace. Workspaces other than the default one
+// are kept in the workspaces folder next to the default database
+func WorkspacePath(name string) (string, error) {
+	if name == DefaultWorkspace {
+		if path, err := config.GetDatabasePath(); err != nil || path != "" {
+			return path, err
+		}
+	}
+
+	dir, err := workspacesDir()
+	if err != nil {
+		return "", err
+	}
+
+	if name == DefaultWorkspace {
+		return filepath.Join(filepath.Dir(dir), "hinoki.db"), nil
+	}
+	return filepath.Join(dir, name+".db"), nil
+}
+
+func workspacesDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "hinoki-cli", "workspaces"), nil
+}
+
+// ListWorkspaces returns the default workspace followed by the other workspaces by name
+func ListWorkspaces() ([]string, error) {
+	dir, err := workspacesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read workspaces: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".db")
+		if ok && !entry.IsDir() && name != DefaultWorkspace && config.ValidateWorkspaceName(name) == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return append([]string{DefaultWorkspace}, names...), nil
+}
+
+// SwitchWorkspace closes the open database and opens the database of the named workspace,
+// creating it if it doesn't exist yet. The open database is kept if the switch fails
+func SwitchWorkspace(name string) error {
+	if err := config.ValidateWorkspaceName(name); err != nil {
+		return err
+	}
+
+	path, err := WorkspacePath(name)
+	if err != nil {
+		return err
+	}
+
+	err = reopenDB(path, func() error {
+		return os.MkdirAll(filepath.Dir(path), 0700)
+	})
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	workspace = name
+	pathOverride = ""
+	mu.Unlock()
+
+	return nil
+}
+
+// backupDir returns the backup directory of the open database. Backups of workspaces other
+// than the default one are kept in a folder named after the workspace
+func backupDir() (string, error) {
+	dir, err := config.GetBackupDir()
+	if err != nil {
+		return "", err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if name := currentWorkspace(); pathOverride == "" && name != DefaultWorkspace {
+		dir = filepath.Join(dir, name)
+	}
+	return dir, nil
+}
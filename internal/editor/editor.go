@@ -0,0 +1,69 @@
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const defaultEditor = "vi"
+
+// FinishedMsg is sent when the editor opened by Edit exits
+type FinishedMsg struct {
+	// ID is the identifier passed to Edit, so screens can tell their edits apart
+	ID   string
+	Text string
+	Err  error
+}
+
+// Edit suspends the program, opens text in $VISUAL or $EDITOR and sends a FinishedMsg with the edited text
+func Edit(id string, text string) tea.Cmd {
+	file, err := os.CreateTemp("", "hinoki-*.md")
+	if err != nil {
+		return finished(id, "", fmt.Errorf("failed to create temp file: %w", err))
+	}
+
+	path := file.Name()
+	_, err = file.WriteString(text)
+	file.Close()
+	if err != nil {
+		os.Remove(path)
+		return finished(id, "", fmt.Errorf("failed to write temp file: %w", err))
+	}
+
+	args := strings.Fields(editorCommand())
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+
+		if err != nil {
+			return FinishedMsg{ID: id, Err: fmt.Errorf("editor failed: %w", err)}
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return FinishedMsg{ID: id, Err: fmt.Errorf("failed to read temp file: %w", err)}
+		}
+
+		return FinishedMsg{ID: id, Text: strings.TrimSpace(string(content))}
+	})
+}
+
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	return defaultEditor
+}
+
+func finished(id string, text string, err error) tea.Cmd {
+	return func() tea.Msg {
+		return FinishedMsg{ID: id, Text: text, Err: err}
+	}
+}
@@ -0,0 +1,265 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/repository"
+)
+
+const (
+	// Format identifies hinoki export documents
+	Format = "hinoki-export"
+	// Version is the version of the export document layout
+	Version = 1
+)
+
+type Mode string
+
+const (
+	// Merge upserts goals by ID and keeps goals that are not in the document
+	Merge Mode = "merge"
+	// Replace deletes all existing goals before importing
+	Replace Mode = "replace"
+)
+
+// Document is a portable snapshot of the whole database
+type Document struct {
+	Format        string      `json:"format"`
+	Version       int         `json:"version"`
+	SchemaVersion int         `json:"schemaVersion"`
+	ExportedAt    time.Time   `json:"exportedAt"`
+	Goals         []goal.Goal `json:"goals"`
+	// Series are the rules of repeating goals, missing in documents exported before they existed
+	Series []repository.Series `json:"series"`
+	// Events are the change history of the goals, missing in documents exported before it existed
+	Events []goal.Event `json:"events"`
+	// PeriodNotes are the journals and review reflections of periods
+	PeriodNotes []repository.PeriodNote `json:"periodNotes"`
+}
+
+// Build collects every goal, including archived ones, into a document
+func Build() (Document, error) {
+	schemaVersion, err := db.SchemaVersion()
+	if err != nil {
+		return Document{}, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	goals, err := repository.GetAllGoals()
+	if err != nil {
+		return Document{}, fmt.Errorf("failed to read goals: %w", err)
+	}
+
+	if goals == nil {
+		goals = []goal.Goal{}
+	}
+
+	series, err := repository.GetAllSeries()
+	if err != nil {
+		return Document{}, fmt.Errorf("failed to read series: %w", err)
+	}
+
+	if series == nil {
+		series = []repository.Series{}
+	}
+
+	events, err := repository.GetAllEvents()
+	if err != nil {
+		return Document{}, fmt.Errorf("failed to read goal history: %w", err)
+	}
+
+	if events == nil {
+		events = []goal.Event{}
+	}
+
+	notes, err := repository.GetAllPeriodNotes()
+	if err != nil {
+		return Document{}, fmt.Errorf("failed to read period notes: %w", err)
+	}
+
+	if notes == nil {
+		notes = []repository.PeriodNote{}
+	}
+
+	return Document{
+		Format:        Format,
+		Version:       Version,
+		SchemaVersion: schemaVersion,
+		ExportedAt:    time.Now(),
+		Goals:         goals,
+		Series:        series,
+		Events:        events,
+		PeriodNotes:   notes,
+	}, nil
+}
+
+// Write encodes the document as indented JSON
+func Write(w io.Writer, doc Document) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// Read decodes a document and checks that this build understands it
+func Read(r io.Reader) (Document, error) {
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return Document{}, fmt.Errorf("invalid export document: %w", err)
+	}
+
+	if doc.Format != Format {
+		return Document{}, fmt.Errorf("not a hinoki export document")
+	}
+
+	if doc.Version > Version {
+		return Document{}, fmt.Errorf("export version %d is newer than supported version %d", doc.Version, Version)
+	}
+
+	if latest := db.LatestSchemaVersion(); doc.SchemaVersion > latest {
+		return Document{}, fmt.Errorf("export schema version %d is newer than supported version %d", doc.SchemaVersion, latest)
+	}
+
+	return doc, nil
+}
+
+// Import validates the document and writes its goals, series, goal history and period notes to the database
+func Import(doc Document, mode Mode) error {
+	var existing []goal.Goal
+
+	switch mode {
+	case Replace:
+	case Merge:
+		var err error
+		if existing, err = repository.GetAllGoals(); err != nil {
+			return fmt.Errorf("failed to read goals: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown import mode: %s", mode)
+	}
+
+	if err := Validate(doc.Goals, existing); err != nil {
+		return err
+	}
+
+	if err := validateSeries(doc.Series); err != nil {
+		return err
+	}
+
+	if err := validateEvents(doc.Events, doc.Goals, existing); err != nil {
+		return err
+	}
+
+	for _, note := range doc.PeriodNotes {
+		if note.Timeframe.String() == "" || note.Timeframe == goal.Life {
+			return fmt.Errorf("period note has invalid timeframe %q", note.Timeframe)
+		}
+	}
+
+	return repository.ImportSnapshot(repository.Snapshot{Goals: doc.Goals, Series: doc.Series, Events: doc.Events, PeriodNotes: doc.PeriodNotes}, mode == Replace)
+}
+
+// Validate checks that goals are well formed, that every ParentId points to a goal in the
+// document or to one of the existing goals, and that merging them creates no parent cycle
+func Validate(goals []goal.Goal, existing []goal.Goal) error {
+	known := make(map[string]bool, len(goals)+len(existing))
+	for _, g := range existing {
+		known[g.ID] = true
+	}
+
+	seen := make(map[string]bool, len(goals))
+	for _, g := range goals {
+		if g.ID == "" {
+			return fmt.Errorf("goal %q has no id", g.Title)
+		}
+		if seen[g.ID] {
+			return fmt.Errorf("duplicate goal id %s", g.ID)
+		}
+		seen[g.ID] = true
+		known[g.ID] = true
+
+		if g.Title == "" {
+			return fmt.Errorf("goal %s has no title", g.ID)
+		}
+
+		if g.Timeframe != nil && g.Timeframe.String() == "" {
+			return fmt.Errorf("goal %s has invalid timeframe %q", g.ID, *g.Timeframe)
+		}
+	}
+
+	for _, g := range goals {
+		if g.ParentId == nil {
+			continue
+		}
+		if *g.ParentId == g.ID {
+			return fmt.Errorf("goal %s is its own parent", g.ID)
+		}
+		if !known[*g.ParentId] {
+			return fmt.Errorf("goal %s references unknown parent %s", g.ID, *g.ParentId)
+		}
+	}
+
+	return checkCycles(goals, existing)
+}
+
+// validateSeries checks that every series has an id and a rule this build can generate goals by
+func validateSeries(series []repository.Series) error {
+	for _, s := range series {
+		if s.ID == "" {
+			return fmt.Errorf("series %q has no id", s.Title)
+		}
+		if _, err := goal.ParseRecurrence(string(s.Rule)); err != nil {
+			return fmt.Errorf("series %s: %w", s.ID, err)
+		}
+	}
+	return nil
+}
+
+// validateEvents checks that every event belongs to a goal of the document or to an existing goal
+func validateEvents(events []goal.Event, goals []goal.Goal, existing []goal.Goal) error {
+	known := make(map[string]bool, len(goals)+len(existing))
+	for _, g := range existing {
+		known[g.ID] = true
+	}
+	for _, g := range goals {
+		known[g.ID] = true
+	}
+
+	for _, e := range events {
+		if !known[e.GoalID] {
+			return fmt.Errorf("%s event references unknown goal %s", e.Kind, e.GoalID)
+		}
+	}
+	return nil
+}
+
+// checkCycles follows the parent chain of every goal in the document, through the existing goals
+// as they are after the import, and rejects goals that are their own ancestor, which would send
+// the recursive hierarchy queries in circles
+func checkCycles(goals []goal.Goal, existing []goal.Goal) error {
+	parents := make(map[string]string, len(goals)+len(existing))
+	for _, list := range [][]goal.Goal{existing, goals} {
+		for _, g := range list {
+			if g.ParentId != nil {
+				parents[g.ID] = *g.ParentId
+			} else {
+				delete(parents, g.ID)
+			}
+		}
+	}
+
+	for _, g := range goals {
+		visited := map[string]bool{g.ID: true}
+		for id, ok := parents[g.ID]; ok; id, ok = parents[id] {
+			if visited[id] {
+				return fmt.Errorf("goal %s is part of a parent cycle", g.ID)
+			}
+			visited[id] = true
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+package export
+
+import (
+	"bytes"
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/repository"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestMain runs the tests against a database and config file of their own
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "hinoki-export")
+	if err != nil {
+		panic(err)
+	}
+
+	os.Setenv("HINOKI_CONFIG", filepath.Join(dir, "config.ini"))
+	db.SetPath(filepath.Join(dir, "hinoki.db"))
+	db.InitDB()
+
+	code := m.Run()
+
+	db.CloseDB()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// exportDocument exports the database and reads the export back, like export and import do
+func exportDocument(t *testing.T) Document {
+	t.Helper()
+
+	doc, err := Build()
+	if err != nil {
+		t.Fatalf("Build returned %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, doc); err != nil {
+		t.Fatalf("Write returned %v", err)
+	}
+
+	doc, err = Read(&buf)
+	if err != nil {
+		t.Fatalf("Read returned %v", err)
+	}
+	return doc
+}
+
+// roundTrip exports the database, then imports the export in place of everything
+func roundTrip(t *testing.T) {
+	t.Helper()
+
+	if err := Import(exportDocument(t), Replace); err != nil {
+		t.Fatalf("Import returned %v", err)
+	}
+}
+
+func TestImport_RoundTripKeepsRecurrence(t *testing.T) {
+	if err := Import(Document{}, Replace); err != nil {
+		t.Fatalf("Import returned %v", err)
+	}
+
+	today := time.Now()
+	timeframe := goal.Day
+	g := goal.Goal{ID: uuid.New().String(), Title: "Stretch", Timeframe: &timeframe, Date: &today}
+	if err := repository.AddGoal(g); err != nil {
+		t.Fatalf("AddGoal returned %v", err)
+	}
+	if err := repository.SetGoalRecurrence(g, goal.Daily); err != nil {
+		t.Fatalf("SetGoalRecurrence returned %v", err)
+	}
+
+	roundTrip(t)
+
+	imported, err := repository.GetGoalByID(g.ID)
+	if err != nil || imported == nil {
+		t.Fatalf("GetGoalByID returned %v, %v", imported, err)
+	}
+	if imported.SeriesID == nil || imported.Recurrence != goal.Daily {
+		t.Errorf("imported goal has series %v and recurrence %q; want the daily series", imported.SeriesID, imported.Recurrence)
+	}
+
+	tomorrow := dates.StartOfPeriod(today, goal.Day).AddDate(0, 0, 1)
+	goals, err := repository.GetGoalsByDate(goal.Day, tomorrow)
+	if err != nil {
+		t.Fatalf("GetGoalsByDate returned %v", err)
+	}
+	if len(goals) != 1 || goals[0].Title != "Stretch" {
+		t.Errorf("goals of tomorrow %v; want the next Stretch goal", goals)
+	}
+}
+
+func TestValidate_ParentInDocument(t *testing.T) {
+	parentID := "parent"
+	goals := []goal.Goal{
+		{ID: "child", Title: "Child", ParentId: &parentID},
+		{ID: "parent", Title: "Parent"},
+	}
+
+	if err := Validate(goals, nil); err != nil {
+		t.Errorf("Validate returned %v; want nil", err)
+	}
+}
+
+func TestValidate_ParentInExistingGoals(t *testing.T) {
+	parentID := "existing"
+	goals := []goal.Goal{
+		{ID: "child", Title: "Child", ParentId: &parentID},
+	}
+
+	if err := Validate(goals, []goal.Goal{{ID: "existing", Title: "Existing"}}); err != nil {
+		t.Errorf("Validate returned %v; want nil", err)
+	}
+}
+
+func TestValidate_ParentCycleThroughExistingGoals(t *testing.T) {
+	a, b := "a", "b"
+	existing := []goal.Goal{
+		{ID: "a", Title: "A", ParentId: &b},
+		{ID: "b", Title: "B"},
+	}
+	goals := []goal.Goal{
+		{ID: "b", Title: "B", ParentId: &a},
+	}
+
+	if err := Validate(goals, existing); err == nil {
+		t.Errorf("Validate should reject parent cycles with existing goals")
+	}
+}
+
+func TestValidate_UnknownParent(t *testing.T) {
+	parentID := "missing"
+	goals := []goal.Goal{
+		{ID: "child", Title: "Child", ParentId: &parentID},
+	}
+
+	if err := Validate(goals, nil); err == nil {
+		t.Errorf("Validate should reject unknown parent")
+	}
+}
+
+func TestValidate_DuplicateID(t *testing.T) {
+	goals := []goal.Goal{
+		{ID: "a", Title: "First"},
+		{ID: "a", Title: "Second"},
+	}
+
+	if err := Validate(goals, nil); err == nil {
+		t.Errorf("Validate should reject duplicate ids")
+	}
+}
+
+func TestValidate_InvalidTimeframe(t *testing.T) {
+	timeframe := goal.Timeframe("decade")
+	goals := []goal.Goal{
+		{ID: "a", Title: "Goal", Timeframe: &timeframe},
+	}
+
+	if err := Validate(goals, nil); err == nil {
+		t.Errorf("Validate should reject unknown timeframes")
+	}
+}
+
+func TestValidate_ParentCycle(t *testing.T) {
+	a, b := "a", "b"
+	goals := []goal.Goal{
+		{ID: "a", Title: "A", ParentId: &b},
+		{ID: "b", Title: "B", ParentId: &a},
+	}
+
+	if err := Validate(goals, nil); err == nil {
+		t.Errorf("Validate should reject parent cycles")
+	}
+}
+
+func TestImport_RoundTripKeepsHistory(t *testing.T) {
+	if err := Import(Document{}, Replace); err != nil {
+		t.Fatalf("Import returned %v", err)
+	}
+
+	g := goal.Goal{ID: uuid.New().String(), Title: "Read"}
+	if err := repository.AddGoal(g); err != nil {
+		t.Fatalf("AddGoal returned %v", err)
+	}
+	g.IsDone = true
+	if err := repository.UpdateGoal(g); err != nil {
+		t.Fatalf("UpdateGoal returned %v", err)
+	}
+
+	before, err := repository.GetGoalEvents(g.ID)
+	if err != nil || len(before) == 0 {
+		t.Fatalf("GetGoalEvents returned %v, %v", before, err)
+	}
+
+	if err := Import(exportDocument(t), Merge); err != nil {
+		t.Fatalf("Import returned %v", err)
+	}
+
+	merged, err := repository.GetGoalEvents(g.ID)
+	if err != nil {
+		t.Fatalf("GetGoalEvents returned %v", err)
+	}
+	if len(merged) != len(before) {
+		t.Errorf("history has %d events after merge; want %d", len(merged), len(before))
+	}
+
+	roundTrip(t)
+
+	after, err := repository.GetGoalEvents(g.ID)
+	if err != nil {
+		t.Fatalf("GetGoalEvents returned %v", err)
+	}
+	if len(after) != len(before) {
+		t.Errorf("history has %d events after replace; want %d", len(after), len(before))
+	}
+}
+
+func TestImport_MergeKeepsUpdatedAt(t *testing.T) {
+	if err := Import(Document{}, Replace); err != nil {
+		t.Fatalf("Import returned %v", err)
+	}
+
+	g := goal.Goal{ID: uuid.New().String(), Title: "Plan trip"}
+	if err := repository.AddGoal(g); err != nil {
+		t.Fatalf("AddGoal returned %v", err)
+	}
+	if _, err := db.ExecQuery("UPDATE goals SET updated_at = '2020-01-02 03:04:05' WHERE id = ?", g.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Merging the unchanged goal over itself must not count as an update. The second import
+	// writes the same value the first one did
+	doc := exportDocument(t)
+	for range 2 {
+		if err := Import(doc, Merge); err != nil {
+			t.Fatalf("Import returned %v", err)
+		}
+	}
+
+	merged, err := repository.GetGoalByID(g.ID)
+	if err != nil {
+		t.Fatalf("GetGoalByID returned %v", err)
+	}
+	want := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if !merged.UpdatedAt.Equal(want) {
+		t.Errorf("updated_at after merge = %v; want %v", merged.UpdatedAt, want)
+	}
+}
+
+func TestImport_RoundTripKeepsPeriodNotes(t *testing.T) {
+	if err := Import(Document{}, Replace); err != nil {
+		t.Fatalf("Import returned %v", err)
+	}
+
+	week := time.Now()
+	if err := repository.SavePeriodNote(goal.Week, week, "Ship the release"); err != nil {
+		t.Fatalf("SavePeriodNote returned %v", err)
+	}
+
+	doc := exportDocument(t)
+
+	// Replacing drops notes that are not in the document
+	nextMonth := week.AddDate(0, 1, 0)
+	if err := repository.SavePeriodNote(goal.Month, nextMonth, "Not exported"); err != nil {
+		t.Fatalf("SavePeriodNote returned %v", err)
+	}
+
+	if err := Import(doc, Replace); err != nil {
+		t.Fatalf("Import returned %v", err)
+	}
+
+	if text, err := repository.GetPeriodNote(goal.Week, week); err != nil || text != "Ship the release" {
+		t.Errorf("week note %q, %v; want %q", text, err, "Ship the release")
+	}
+	if text, err := repository.GetPeriodNote(goal.Month, nextMonth); err != nil || text != "" {
+		t.Errorf("month note %q, %v; want none", text, err)
+	}
+}
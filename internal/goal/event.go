@@ -0,0 +1,92 @@
+package goal
+
+import "time"
+
+// EventKind is the kind of change recorded in the history of a goal
+type EventKind string
+
+const (
+	Created       EventKind = "created"
+	Renamed       EventKind = "renamed"
+	StatusChanged EventKind = "status"
+	Rescheduled   EventKind = "rescheduled"
+	Reparented    EventKind = "reparented"
+	Archived      EventKind = "archived"
+)
+
+// Event is a change of a goal with the values before and after it. Empty values mean none,
+// such as no parent or no period
+type Event struct {
+	ID        int64     `json:"id"`
+	GoalID    string    `json:"goalId"`
+	Kind      EventKind `json:"kind"`
+	OldValue  string    `json:"oldValue"`
+	NewValue  string    `json:"newValue"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Events returns the events that turn before into after. A nil before means after was created
+func Events(before *Goal, after Goal) []Event {
+	event := func(kind EventKind, oldValue, newValue string) Event {
+		return Event{GoalID: after.ID, Kind: kind, OldValue: oldValue, NewValue: newValue}
+	}
+
+	if before == nil {
+		return []Event{event(Created, "", after.Title)}
+	}
+
+	var events []Event
+
+	if before.Title != after.Title {
+		events = append(events, event(Renamed, before.Title, after.Title))
+	}
+	if before.IsDone != after.IsDone {
+		events = append(events, event(StatusChanged, status(before.IsDone), status(after.IsDone)))
+	}
+	if oldPeriod, newPeriod := Period(*before), Period(after); oldPeriod != newPeriod {
+		events = append(events, event(Rescheduled, oldPeriod, newPeriod))
+	}
+	if value(before.ParentId) != value(after.ParentId) {
+		events = append(events, event(Reparented, value(before.ParentTitle), value(after.ParentTitle)))
+	}
+	if before.IsArchived != after.IsArchived {
+		events = append(events, event(Archived, archived(before.IsArchived), archived(after.IsArchived)))
+	}
+
+	return events
+}
+
+// Period describes the period a goal is planned for, e.g. "Week 2024-06-03" or "Life"
+func Period(g Goal) string {
+	switch {
+	case g.Timeframe == nil:
+		return ""
+	case *g.Timeframe == Life:
+		return Life.String()
+	case g.Date == nil:
+		return g.Timeframe.String()
+	}
+
+	return g.Timeframe.String() + " " + g.Date.Format("2006-01-02")
+}
+
+func status(isDone bool) string {
+	if isDone {
+		return "done"
+	}
+	return "undone"
+}
+
+func archived(isArchived bool) string {
+	if isArchived {
+		return "archived"
+	}
+	return "active"
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
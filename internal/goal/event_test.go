@@ -0,0 +1,63 @@
+package goal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvents(t *testing.T) {
+	week := Week
+	date := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	parentID, parentTitle := "p", "Parent"
+
+	before := Goal{ID: "g", Title: "Run", Timeframe: &week, Date: &date}
+	after := before
+	after.Title = "Run 5k"
+	after.IsDone = true
+	after.ParentId, after.ParentTitle = &parentID, &parentTitle
+
+	events := Events(&before, after)
+
+	expected := []Event{
+		{GoalID: "g", Kind: Renamed, OldValue: "Run", NewValue: "Run 5k"},
+		{GoalID: "g", Kind: StatusChanged, OldValue: "undone", NewValue: "done"},
+		{GoalID: "g", Kind: Reparented, OldValue: "", NewValue: "Parent"},
+	}
+
+	if len(events) != len(expected) {
+		t.Fatalf("Events = %+v; want %+v", events, expected)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("event %d = %+v; want %+v", i, events[i], expected[i])
+		}
+	}
+}
+
+func TestEvents_Created(t *testing.T) {
+	events := Events(nil, Goal{ID: "g", Title: "Run"})
+
+	if len(events) != 1 || events[0].Kind != Created || events[0].NewValue != "Run" {
+		t.Errorf("Events = %+v; want a single created event", events)
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	day, life := Day, Life
+	date := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		goal     Goal
+		expected string
+	}{
+		{Goal{}, ""},
+		{Goal{Timeframe: &life}, "Life"},
+		{Goal{Timeframe: &day, Date: &date}, "Day 2024-06-03"},
+	}
+
+	for _, c := range cases {
+		if got := Period(c.goal); got != c.expected {
+			t.Errorf("Period = %q; want %q", got, c.expected)
+		}
+	}
+}
@@ -35,14 +35,22 @@ func (t Timeframe) String() string {
 }
 
 type Goal struct {
-	ID          string     `json:"id"`
-	ParentId    *string    `json:"parent_id"`
-	ParentTitle *string    `json:"parent_title"`
-	CreatedAt   time.Time  `json:"createdAt" validate:"datetime=2006-01-02T15:04:05.999999"`
-	UpdatedAt   time.Time  `json:"updatedAt" validate:"datetime=2006-01-02T15:04:05.999999"`
-	Title       string     `json:"title"`
-	IsDone      bool       `json:"isDone"`
-	Timeframe   *Timeframe `json:"timeframe"`
-	Date        *time.Time `json:"date"`
-	IsArchived  bool       `json:"isArchived"`
+	ID           string     `json:"id"`
+	ParentId     *string    `json:"parent_id"`
+	ParentTitle  *string    `json:"parent_title"`
+	CreatedAt    time.Time  `json:"createdAt" validate:"datetime=2006-01-02T15:04:05.999999"`
+	UpdatedAt    time.Time  `json:"updatedAt" validate:"datetime=2006-01-02T15:04:05.999999"`
+	Title        string     `json:"title"`
+	Notes        string     `json:"notes"`
+	Tags         []string   `json:"tags"`
+	IsDone       bool       `json:"isDone"`
+	Timeframe    *Timeframe `json:"timeframe"`
+	Date         *time.Time `json:"date"`
+	IsArchived   bool       `json:"isArchived"`
+	ArchivedAt   *time.Time `json:"archivedAt"`
+	SeriesID     *string    `json:"seriesId"`
+	SeriesPeriod *string    `json:"seriesPeriod"`       // Period the series generated the goal for
+	Recurrence   Recurrence `json:"recurrence"`         // Rule of the active series the goal belongs to, if any
+	Postponed    int        `json:"postponedCount"`     // Times the goal was rolled forward to a later period
+	Progress     *Progress  `json:"progress,omitempty"` // Only set where listed goals are rolled up
 }
@@ -0,0 +1,65 @@
+package goal
+
+// Progress is how much of the subtree below a goal is done
+type Progress struct {
+	Done  int     `json:"done"`
+	Total int     `json:"total"`
+	Ratio float64 `json:"ratio"`
+}
+
+// RollUp computes the progress of the goal rootID from all of its descendants.
+// Unweighted, every descendant counts once. Weighted, every direct child counts
+// equally and contributes the progress of its own subtree
+func RollUp(rootID string, descendants []Goal, weighted bool) Progress {
+	children := make(map[string][]Goal)
+	var progress Progress
+
+	for _, g := range descendants {
+		if g.ParentId == nil {
+			continue
+		}
+		children[*g.ParentId] = append(children[*g.ParentId], g)
+		progress.Total++
+		if g.IsDone {
+			progress.Done++
+		}
+	}
+
+	if progress.Total == 0 {
+		return progress
+	}
+
+	if weighted {
+		progress.Ratio = subtreeRatio(rootID, children, map[string]bool{})
+	} else {
+		progress.Ratio = float64(progress.Done) / float64(progress.Total)
+	}
+
+	return progress
+}
+
+// subtreeRatio averages the ratios of the children of id, a goal without children is done or not
+func subtreeRatio(id string, children map[string][]Goal, visited map[string]bool) float64 {
+	visited[id] = true
+
+	var sum float64
+	var count int
+	for _, child := range children[id] {
+		if visited[child.ID] {
+			continue
+		}
+
+		count++
+		switch {
+		case len(children[child.ID]) > 0:
+			sum += subtreeRatio(child.ID, children, visited)
+		case child.IsDone:
+			sum++
+		}
+	}
+
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
@@ -0,0 +1,43 @@
+package goal
+
+import (
+	"math"
+	"testing"
+)
+
+func progressTree() []Goal {
+	root, a := "root", "a"
+	return []Goal{
+		{ID: "a", ParentId: &root},
+		{ID: "a1", ParentId: &a, IsDone: true},
+		{ID: "a2", ParentId: &a, IsDone: true},
+		{ID: "a3", ParentId: &a},
+		{ID: "b", ParentId: &root, IsDone: true},
+	}
+}
+
+func TestRollUp(t *testing.T) {
+	progress := RollUp("root", progressTree(), false)
+
+	if progress.Done != 3 || progress.Total != 5 || progress.Ratio != 0.6 {
+		t.Errorf("progress %+v; want 3/5 done", progress)
+	}
+}
+
+func TestRollUp_Weighted(t *testing.T) {
+	progress := RollUp("root", progressTree(), true)
+
+	// a is 2/3 done and b is done, so each child weighs half
+	expected := (2.0/3.0 + 1) / 2
+	if progress.Done != 3 || progress.Total != 5 || math.Abs(progress.Ratio-expected) > 1e-9 {
+		t.Errorf("progress %+v; want ratio %f", progress, expected)
+	}
+}
+
+func TestRollUp_NoDescendants(t *testing.T) {
+	progress := RollUp("root", nil, true)
+
+	if progress.Total != 0 || progress.Ratio != 0 {
+		t.Errorf("progress %+v; want empty", progress)
+	}
+}
@@ -0,0 +1,73 @@
+package goal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Recurrence is the rule a recurring goal repeats by
+type Recurrence string
+
+const (
+	Daily        Recurrence = "daily"
+	Weekdays     Recurrence = "weekdays"
+	FirstOfMonth Recurrence = "first-of-month"
+	Weekly       Recurrence = "weekly"
+	Monthly      Recurrence = "monthly"
+	Quarterly    Recurrence = "quarterly"
+	Yearly       Recurrence = "yearly"
+)
+
+var recurrences = []Recurrence{Daily, Weekdays, FirstOfMonth, Weekly, Monthly, Quarterly, Yearly}
+
+// ParseRecurrence parses a rule name such as "weekdays" or "first-of-month"
+func ParseRecurrence(s string) (Recurrence, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for _, r := range recurrences {
+		if string(r) == name {
+			return r, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown recurrence %q", s)
+}
+
+// RecurrencesFor returns the rules that can repeat goals of the timeframe
+func RecurrencesFor(timeframe Timeframe) []Recurrence {
+	var result []Recurrence
+	for _, r := range recurrences {
+		if r.Timeframe() == timeframe {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Timeframe returns the timeframe of the goals generated by the rule
+func (r Recurrence) Timeframe() Timeframe {
+	switch r {
+	case Daily, Weekdays, FirstOfMonth:
+		return Day
+	case Weekly:
+		return Week
+	case Monthly:
+		return Month
+	case Quarterly:
+		return Quarter
+	case Yearly:
+		return Year
+	}
+	return ""
+}
+
+// Matches reports whether the rule generates a goal for the period starting at periodStart
+func (r Recurrence) Matches(periodStart time.Time) bool {
+	switch r {
+	case Weekdays:
+		return periodStart.Weekday() != time.Saturday && periodStart.Weekday() != time.Sunday
+	case FirstOfMonth:
+		return periodStart.Day() == 1
+	}
+	return r.Timeframe() != ""
+}
@@ -0,0 +1,41 @@
+package goal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurrenceMatches(t *testing.T) {
+	saturday := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		rule     Recurrence
+		date     time.Time
+		expected bool
+	}{
+		{Daily, saturday, true},
+		{Weekdays, saturday, false},
+		{Weekdays, monday, true},
+		{FirstOfMonth, saturday, true},
+		{FirstOfMonth, monday, false},
+		{Weekly, monday, true},
+	}
+
+	for _, c := range cases {
+		if got := c.rule.Matches(c.date); got != c.expected {
+			t.Errorf("%s.Matches(%s) = %v; want %v", c.rule, c.date.Format("2006-01-02"), got, c.expected)
+		}
+	}
+}
+
+func TestParseRecurrence(t *testing.T) {
+	rule, err := ParseRecurrence(" Weekdays ")
+	if err != nil || rule != Weekdays {
+		t.Errorf("ParseRecurrence = %q, %v; want %q", rule, err, Weekdays)
+	}
+
+	if _, err := ParseRecurrence("fortnightly"); err == nil {
+		t.Errorf("ParseRecurrence should reject unknown rules")
+	}
+}
@@ -0,0 +1,73 @@
+package goal
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ParseTags extracts inline #tags from the input
+// Returns the input without the tags and the tags, lowercased, deduplicated and sorted
+func ParseTags(input string) (string, []string) {
+	var words []string
+	var tags []string
+	seen := make(map[string]bool)
+
+	for _, word := range strings.Fields(input) {
+		tag, ok := tagFromWord(word)
+		if !ok {
+			words = append(words, word)
+			continue
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Strings(tags)
+
+	return strings.Join(words, " "), tags
+}
+
+// TitleWithTags appends the tags to the title in the inline #tag form understood by ParseTags
+func TitleWithTags(title string, tags []string) string {
+	parts := []string{title}
+	for _, tag := range tags {
+		parts = append(parts, "#"+tag)
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+// HasAnyTag reports whether the goal has at least one of the tags
+func (g Goal) HasAnyTag(tags []string) bool {
+	for _, tag := range tags {
+		for _, goalTag := range g.Tags {
+			if goalTag == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func tagFromWord(word string) (string, bool) {
+	name, ok := strings.CutPrefix(word, "#")
+	if !ok || name == "" {
+		return "", false
+	}
+
+	// Words without a letter, such as issue numbers in "Fix #42", stay in the title
+	hasLetter := false
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return "", false
+		}
+		hasLetter = hasLetter || unicode.IsLetter(r)
+	}
+	if !hasLetter {
+		return "", false
+	}
+
+	return strings.ToLower(name), true
+}
@@ -0,0 +1,56 @@
+package goal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	title, tags := ParseTags("Run 5k #Health every #morning #health")
+
+	if title != "Run 5k every" {
+		t.Errorf("title %q; want %q", title, "Run 5k every")
+	}
+
+	expected := []string{"health", "morning"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("tags %v; want %v", tags, expected)
+	}
+}
+
+func TestParseTags_KeepsNonTags(t *testing.T) {
+	title, tags := ParseTags("Fix issue #42 # 12 and #42! #2024-10 #")
+
+	if title != "Fix issue #42 # 12 and #42! #2024-10 #" {
+		t.Errorf("title %q; want input unchanged", title)
+	}
+
+	if len(tags) != 0 {
+		t.Errorf("tags %v; want none", tags)
+	}
+}
+
+func TestParseTags_DigitsWithLetters(t *testing.T) {
+	_, tags := ParseTags("Plan #2026q1 #5k")
+
+	if !reflect.DeepEqual(tags, []string{"2026q1", "5k"}) {
+		t.Errorf("tags %v; want [2026q1 5k]", tags)
+	}
+}
+
+func TestParseTags_Unicode(t *testing.T) {
+	_, tags := ParseTags("Позвонить маме #семья")
+
+	if !reflect.DeepEqual(tags, []string{"семья"}) {
+		t.Errorf("tags %v; want [семья]", tags)
+	}
+}
+
+func TestTitleWithTags_RoundTrip(t *testing.T) {
+	input := TitleWithTags("Weekly review", []string{"career", "focus"})
+
+	title, tags := ParseTags(input)
+	if title != "Weekly review" || !reflect.DeepEqual(tags, []string{"career", "focus"}) {
+		t.Errorf("round trip of %q gave %q %v", input, title, tags)
+	}
+}
@@ -17,11 +17,15 @@ type GoalItemDelegate struct {
 }
 
 var (
-	doneItemStyle     = lipgloss.NewStyle().Foreground(theme.TextDisabled())
-	selectedItemStyle = lipgloss.NewStyle().Foreground(theme.TextSelected())
+	doneItemStyle     = lipgloss.NewStyle().Foreground(theme.Done())
+	selectedItemStyle = theme.SelectedStyle()
 	parentStyle       = lipgloss.NewStyle().Foreground(theme.TextMuted())
+	overdueStyle      = lipgloss.NewStyle().Foreground(theme.Overdue())
+	tagStyle          = lipgloss.NewStyle().Foreground(theme.Tag()).Italic(true)
 )
 
+const progressBarWidth = 10
+
 func (d GoalItemDelegate) Height() int {
 	return 2
 }
@@ -83,11 +87,30 @@ func (d GoalItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 	}
 
 	dateTimeRendered := parentStyle.Render(dateTime)
+	if dateTime != "" && !i.IsDone && dates.IsOverdue(i.Date, i.Timeframe) {
+		dateTimeRendered = overdueStyle.Render(dateTime)
+	}
+
+	recurrence := ""
+	if i.Recurrence != "" {
+		recurrence = " " + parentStyle.Render("↻")
+	}
 
-	str := fmt.Sprintf("[%s] %s%s", checkmark, i.Title, dateTimeRendered)
+	postponed := ""
+	if i.Postponed > 0 {
+		postponed = " " + parentStyle.Render(fmt.Sprintf("postponed %d×", i.Postponed))
+	}
 
-	// For non-overdue modes, show parent on separate line if exists
-	if i.mode != Overdue && i.ParentId != nil && i.ParentTitle != nil {
+	progress := ""
+	if i.Progress != nil {
+		progress = "  " + ProgressBar(*i.Progress, progressBarWidth)
+	}
+
+	str := fmt.Sprintf("[%s] %s%s%s%s%s%s", checkmark, i.Title, recurrence, postponed, renderTags(i.Tags), progress, dateTimeRendered)
+
+	// For timeframe and inbox modes, show parent on separate line if exists
+	// Subgoals are listed under their parent already
+	if (i.mode == Timeframe || i.mode == Inbox) && i.ParentId != nil && i.ParentTitle != nil {
 		str = fmt.Sprintf("%s\n    %s", str, parentStyle.Render(*i.ParentTitle))
 	}
 
@@ -101,3 +124,12 @@ func (d GoalItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 
 	fmt.Fprint(w, itemStyle.Render(wrapped))
 }
+
+// renderTags renders tags as #tag chips following the title
+func renderTags(tags []string) string {
+	var chips string
+	for _, tag := range tags {
+		chips += " " + tagStyle.Render("#"+tag)
+	}
+	return chips
+}
@@ -6,6 +6,7 @@ const (
 	Timeframe = iota
 	Subgoal
 	Overdue
+	Inbox
 )
 
 type GoalItem struct {
@@ -1,6 +1,11 @@
 package goallist
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 type listKeyMap struct {
 	markGoalDone    key.Binding
@@ -11,41 +16,60 @@ type listKeyMap struct {
 	changeDate      key.Binding
 	openGoalDetails key.Binding
 	showHierarchy   key.Binding
+	repeatGoal      key.Binding
+	undo            key.Binding
+	redo            key.Binding
 }
 
+var (
+	reloadGoalsAction     = keymap.Register("goals", "reload", "Reload goals", "r")
+	markGoalDoneAction    = keymap.Register("goals", "mark_done", "Mark goal done", " ")
+	createGoalAction      = keymap.Register("goals", "create", "Create new goal", "n")
+	editGoalAction        = keymap.Register("goals", "edit", "Edit goal", "e")
+	archiveGoalAction     = keymap.Register("goals", "archive", "Archive goal", "backspace")
+	changeDateAction      = keymap.Register("goals", "change_date", "Change date", "D")
+	openGoalDetailsAction = keymap.Register("goals", "open_details", "Open goal details screen", "enter")
+	showHierarchyAction   = keymap.Register("goals", "show_hierarchy", "Show goal hierarchy", "v")
+	repeatGoalAction      = keymap.Register("goals", "repeat", "Repeat goal", "R")
+	undoAction            = keymap.Register("goals", "undo", "Undo last change", "U")
+	redoAction            = keymap.Register("goals", "redo", "Redo last undone change", "ctrl+r")
+)
+
 func NewListKeyMap() listKeyMap {
 	return listKeyMap{
-		reloadGoals: key.NewBinding(
-			key.WithKeys("r", "к"),
-			key.WithHelp("r", "Reload goals"),
-		),
-		markGoalDone: key.NewBinding(
-			key.WithKeys(" "),
-			key.WithHelp("Spacebar", "Mark goal done"),
-		),
-		createGoal: key.NewBinding(
-			key.WithKeys("n", "т"),
-			key.WithHelp("n", "Create new goal"),
-		),
-		editGoal: key.NewBinding(
-			key.WithKeys("e", "у"),
-			key.WithHelp("e", "Edit goal"),
-		),
-		archiveGoal: key.NewBinding(
-			key.WithKeys("backspace"),
-			key.WithHelp("Backspace", "Archive goal"),
-		),
-		changeDate: key.NewBinding(
-			key.WithKeys("D", "В"),
-			key.WithHelp("D", "Change date"),
-		),
-		openGoalDetails: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("Enter", "Open goal details screen"),
-		),
-		showHierarchy: key.NewBinding(
-			key.WithKeys("v", "м"),
-			key.WithHelp("v", "Show goal hierarchy"),
-		),
+		reloadGoals:     reloadGoalsAction.Binding(),
+		markGoalDone:    markGoalDoneAction.Binding(),
+		createGoal:      createGoalAction.Binding(),
+		editGoal:        editGoalAction.Binding(),
+		archiveGoal:     archiveGoalAction.Binding(),
+		changeDate:      changeDateAction.Binding(),
+		openGoalDetails: openGoalDetailsAction.Binding(),
+		showHierarchy:   showHierarchyAction.Binding(),
+		repeatGoal:      repeatGoalAction.Binding(),
+		undo:            undoAction.Binding(),
+		redo:            redoAction.Binding(),
+	}
+}
+
+// HelpKeys returns the bindings of the goal list for the help overlay
+func (m *GoalList) HelpKeys() []screens.KeyGroup {
+	return []screens.KeyGroup{
+		{Title: "Goals", Bindings: []key.Binding{
+			m.list.KeyMap.CursorUp,
+			m.list.KeyMap.CursorDown,
+			m.keys.createGoal,
+			m.keys.editGoal,
+			m.keys.markGoalDone,
+			m.keys.changeDate,
+			m.keys.repeatGoal,
+			m.keys.archiveGoal,
+			m.keys.reloadGoals,
+			m.keys.openGoalDetails,
+			m.keys.showHierarchy,
+		}},
+		{Title: "History", Bindings: []key.Binding{
+			m.keys.undo,
+			m.keys.redo,
+		}},
 	}
 }
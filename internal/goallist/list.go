@@ -6,6 +6,7 @@ import (
 	"hinoki-cli/internal/repository"
 	"hinoki-cli/internal/screens"
 	"hinoki-cli/internal/theme"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -22,6 +23,7 @@ const (
 	NewGoalInProgress
 	GoalEditing
 	GoalEditDate
+	GoalEditRecurrence
 )
 
 type listState int
@@ -35,7 +37,9 @@ type GoalList struct {
 	date           *time.Time
 	parent         *goal.Goal
 	goalIDToSelect string
-	displayMode    int // Timeframe, Subgoal, or Overdue
+	displayMode    int      // Timeframe, Subgoal, Overdue or Inbox
+	tagFilter      []string // Only goals with any of these tags are listed
+	goals          []goal.Goal
 
 	width, height int
 }
@@ -47,6 +51,8 @@ var (
 
 type GoalsResult struct {
 	Goals []goal.Goal
+	// Timeframe is the timeframe of the listed period, empty for subgoals and the inbox
+	Timeframe goal.Timeframe
 }
 
 type AddGoalSuccess struct{}
@@ -69,14 +75,8 @@ func NewGoalList(timeframe *goal.Timeframe, date *time.Time) GoalList {
 	l.SetShowStatusBar(false)
 	l.SetStatusBarItemName("goal", "goals")
 
-	l.KeyMap.CursorUp = key.NewBinding(
-		key.WithKeys("up", "k", "л"),
-		key.WithHelp("↑/k", "up"),
-	)
-	l.KeyMap.CursorDown = key.NewBinding(
-		key.WithKeys("down", "j", "о"),
-		key.WithHelp("↓/j", "down"),
-	)
+	l.KeyMap.CursorUp = screens.CursorUpKey()
+	l.KeyMap.CursorDown = screens.CursorDownKey()
 
 	actionInput := textinput.New()
 	actionInput.Focus()
@@ -96,7 +96,10 @@ func (m *GoalList) Update(msg tea.Msg) tea.Cmd {
 	case AddGoalSuccess, UpdateGoalSuccess:
 		cmds = append(cmds, m.getGoalsCmd())
 	case GoalsResult:
-		m.handleGoalResult(msg)
+		// Screens with a list per timeframe pass every result to all of them
+		if msg.Timeframe == "" || m.timeframe == nil || msg.Timeframe == *m.timeframe {
+			m.handleGoalResult(msg)
+		}
 	case tea.KeyMsg:
 		cmds = append(cmds, m.handleKeyMsg(msg))
 	}
@@ -111,7 +114,7 @@ func (m *GoalList) Update(msg tea.Msg) tea.Cmd {
 func (m *GoalList) View() string {
 	var actionInput string
 
-	if m.state == NewGoalInProgress || m.state == GoalEditing || m.state == GoalEditDate {
+	if m.state == NewGoalInProgress || m.state == GoalEditing || m.state == GoalEditDate || m.state == GoalEditRecurrence {
 		actionInput = lipgloss.JoinHorizontal(
 			lipgloss.Top,
 			lipgloss.
@@ -153,6 +156,11 @@ func (m *GoalList) SetDate(timeframe goal.Timeframe, date time.Time) {
 	m.date = &date
 }
 
+// Len returns the number of listed goals
+func (m *GoalList) Len() int {
+	return len(m.list.Items())
+}
+
 func (m *GoalList) IsInActiveState() bool {
 	return m.state != Normal
 }
@@ -161,21 +169,29 @@ func (m *GoalList) getGoalsCmd() func() tea.Msg {
 	return func() tea.Msg {
 
 		var goals []goal.Goal
+		var timeframe goal.Timeframe
 		var err error
 
 		if m.parent != nil {
 			// Goals details view subgoals mode
 			goals, err = repository.GetGoalsByParent(m.parent.ID)
+		} else if m.displayMode == Inbox {
+			goals, err = repository.GetUnscheduledGoals()
 		} else if m.timeframe != nil && m.date != nil {
 			// Timeframe mode
-			goals, err = repository.GetGoalsByDate(*m.timeframe, *m.date)
+			timeframe = *m.timeframe
+			goals, err = repository.GetGoalsByDate(timeframe, *m.date)
 		}
 
 		if err != nil {
 			return err
 		}
 
-		return GoalsResult{Goals: goals}
+		if err := repository.FillProgress(goals); err != nil {
+			return err
+		}
+
+		return GoalsResult{Goals: goals, Timeframe: timeframe}
 	}
 }
 
@@ -185,7 +201,7 @@ func (m *GoalList) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 	switch m.state {
 	case Initial:
 		cmds = nil
-	case NewGoalInProgress, GoalEditing, GoalEditDate:
+	case NewGoalInProgress, GoalEditing, GoalEditDate, GoalEditRecurrence:
 		cmds = append(cmds, m.handleActionInputKeyMsg(msg))
 	case Normal:
 		cmds = append(cmds, m.handleKeyMsgInNormalState(msg))
@@ -204,16 +220,14 @@ func (m *GoalList) handleKeyMsgInNormalState(msg tea.KeyMsg) tea.Cmd {
 		item.IsDone = !item.IsDone
 		return m.updateGoalCmd(item.Goal)
 	case key.Matches(msg, m.keys.createGoal):
-		m.actionInput.Prompt = "[ ] "
-		m.actionInput.Placeholder = "New goal..."
-		m.state = NewGoalInProgress
+		m.StartNewGoal()
 	case key.Matches(msg, m.keys.editGoal):
 		if len(m.list.Items()) == 0 {
 			return nil
 		}
 
 		m.actionInput.Placeholder = ""
-		m.actionInput.SetValue(item.Title)
+		m.actionInput.SetValue(goal.TitleWithTags(item.Title, item.Tags))
 		m.actionInput.Prompt = "Edit: "
 		m.state = GoalEditing
 	case key.Matches(msg, m.keys.reloadGoals):
@@ -222,13 +236,33 @@ func (m *GoalList) handleKeyMsgInNormalState(msg tea.KeyMsg) tea.Cmd {
 		item.IsArchived = true
 		return m.updateGoalCmd(item.Goal)
 	case key.Matches(msg, m.keys.changeDate):
-		if len(m.list.Items()) == 0 {
+		m.StartDateChange("Change date: ")
+	case key.Matches(msg, m.keys.repeatGoal):
+		if len(m.list.Items()) == 0 || item.Timeframe == nil || item.Date == nil {
 			return nil
 		}
 
-		m.actionInput.Placeholder = ""
-		m.actionInput.Prompt = "Change date: "
-		m.state = GoalEditDate
+		rules := goal.RecurrencesFor(*item.Timeframe)
+		if len(rules) == 0 {
+			return nil
+		}
+
+		names := make([]string, len(rules))
+		for i, rule := range rules {
+			names[i] = string(rule)
+		}
+
+		m.actionInput.Placeholder = strings.Join(names, ", ")
+		if item.Recurrence != "" {
+			m.actionInput.Placeholder += " or empty to stop"
+		}
+		m.actionInput.SetValue(string(item.Recurrence))
+		m.actionInput.Prompt = "Repeat: "
+		m.state = GoalEditRecurrence
+	case key.Matches(msg, m.keys.undo):
+		return m.historyCmd(repository.Undo)
+	case key.Matches(msg, m.keys.redo):
+		return m.historyCmd(repository.Redo)
 	case key.Matches(msg, m.keys.openGoalDetails):
 		if len(m.list.Items()) == 0 {
 			return nil
@@ -246,6 +280,24 @@ func (m *GoalList) handleKeyMsgInNormalState(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+// StartNewGoal opens the input for a new goal in the listed period or parent
+func (m *GoalList) StartNewGoal() {
+	m.actionInput.Prompt = "[ ] "
+	m.actionInput.Placeholder = "New goal..."
+	m.state = NewGoalInProgress
+}
+
+// StartDateChange opens the input that moves the selected goal to the period parsed from a date
+func (m *GoalList) StartDateChange(prompt string) {
+	if len(m.list.Items()) == 0 {
+		return
+	}
+
+	m.actionInput.Placeholder = ""
+	m.actionInput.Prompt = prompt
+	m.state = GoalEditDate
+}
+
 func (m *GoalList) handleActionInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 	var cmd tea.Cmd
 	item, _ := m.list.SelectedItem().(GoalItem)
@@ -257,13 +309,36 @@ func (m *GoalList) handleActionInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 	case tea.KeyEnter:
 		switch m.state {
 		case GoalEditing:
-			item.Title = m.actionInput.Value()
+			item.Title, item.Tags = goal.ParseTags(m.actionInput.Value())
+			m.actionInput.SetValue("")
+			if item.Recurrence != "" {
+				cmd = m.updateSeriesGoalCmd(item.Goal)
+			} else {
+				cmd = m.updateGoalCmd(item.Goal)
+			}
+		case GoalEditRecurrence:
+			value := strings.TrimSpace(m.actionInput.Value())
 			m.actionInput.SetValue("")
-			cmd = m.updateGoalCmd(item.Goal)
+			m.state = Normal
+
+			if value == "" {
+				if item.Recurrence == "" {
+					return nil
+				}
+				return m.stopRecurrenceCmd(item.Goal)
+			}
+
+			rule, err := goal.ParseRecurrence(value)
+			if err != nil || rule == item.Recurrence {
+				return nil
+			}
+
+			return m.setRecurrenceCmd(item.Goal, rule)
 		case GoalEditDate:
 			date, timeframe, err := dates.ParseDate(time.Now(), m.actionInput.Value())
 			m.actionInput.SetValue("")
 			if err != nil {
+				m.state = Normal
 				return nil
 			}
 
@@ -277,7 +352,13 @@ func (m *GoalList) handleActionInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 				parentID = &m.parent.ID
 			}
 
-			goal := goal.Goal{ID: uuid.New().String(), ParentId: parentID, Title: m.actionInput.Value(), Date: m.date, Timeframe: m.timeframe}
+			title, tags := goal.ParseTags(m.actionInput.Value())
+			// Keep new goals visible when the list is filtered by tags
+			if len(tags) == 0 {
+				tags = m.tagFilter
+			}
+
+			goal := goal.Goal{ID: uuid.New().String(), ParentId: parentID, Title: title, Tags: tags, Date: m.date, Timeframe: m.timeframe}
 			m.actionInput.SetValue("")
 
 			return m.addGoalCmd(goal)
@@ -293,7 +374,12 @@ func (m *GoalList) handleActionInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
 
 func (m *GoalList) handleGoalResult(msg GoalsResult) {
 	m.state = Normal
+	m.goals = msg.Goals
+	m.setItems()
+}
 
+// setItems fills the list with the loaded goals that pass the tag filter
+func (m *GoalList) setItems() {
 	var items []list.Item
 
 	mode := m.displayMode
@@ -302,7 +388,10 @@ func (m *GoalList) handleGoalResult(msg GoalsResult) {
 		mode = Subgoal
 	}
 
-	for _, goal := range msg.Goals {
+	for _, goal := range m.goals {
+		if len(m.tagFilter) > 0 && !goal.HasAnyTag(m.tagFilter) {
+			continue
+		}
 		items = append(items, GoalItem{Goal: goal, mode: mode})
 	}
 	m.list.SetItems(items)
@@ -330,6 +419,16 @@ func (m *GoalList) SetGoalIDToSelect(goalID string) {
 	m.goalIDToSelect = goalID
 }
 
+// SetTagFilter limits the list to goals with any of the tags, an empty filter shows all goals
+func (m *GoalList) SetTagFilter(tags []string) {
+	m.tagFilter = tags
+	m.setItems()
+}
+
+func (m *GoalList) TagFilter() []string {
+	return m.tagFilter
+}
+
 func (m *GoalList) SetDisplayMode(mode int) {
 	m.displayMode = mode
 }
@@ -356,6 +455,52 @@ func (m *GoalList) addGoalCmd(goal goal.Goal) func() tea.Msg {
 	}
 }
 
+// updateSeriesGoalCmd saves a repeating goal and carries its title and tags over to later periods
+func (m *GoalList) updateSeriesGoalCmd(goal goal.Goal) func() tea.Msg {
+	return func() tea.Msg {
+		if err := repository.UpdateSeriesGoal(goal); err != nil {
+			return err
+		}
+
+		return UpdateGoalSuccess{}
+	}
+}
+
+func (m *GoalList) setRecurrenceCmd(goal goal.Goal, rule goal.Recurrence) func() tea.Msg {
+	return func() tea.Msg {
+		if err := repository.SetGoalRecurrence(goal, rule); err != nil {
+			return err
+		}
+
+		return UpdateGoalSuccess{}
+	}
+}
+
+func (m *GoalList) stopRecurrenceCmd(goal goal.Goal) func() tea.Msg {
+	return func() tea.Msg {
+		if err := repository.StopGoalRecurrence(goal); err != nil {
+			return err
+		}
+
+		return UpdateGoalSuccess{}
+	}
+}
+
+// historyCmd undoes or redoes a change, which may belong to goals of any screen
+func (m *GoalList) historyCmd(move func() (bool, error)) func() tea.Msg {
+	return func() tea.Msg {
+		moved, err := move()
+		if err != nil {
+			return err
+		}
+		if !moved {
+			return nil
+		}
+
+		return UpdateGoalSuccess{}
+	}
+}
+
 func (m *GoalList) updateGoalCmd(goal goal.Goal) func() tea.Msg {
 	return func() tea.Msg {
 		err := repository.UpdateGoal(goal)
@@ -0,0 +1,28 @@
+package goallist
+
+import (
+	"fmt"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/theme"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	progressFilledStyle = lipgloss.NewStyle().Foreground(theme.TextSecondary())
+	progressEmptyStyle  = lipgloss.NewStyle().Foreground(theme.TextMuted())
+)
+
+// ProgressBar renders the progress of a goal as a bar width cells wide followed by
+// the percentage and the number of done descendants
+func ProgressBar(progress goal.Progress, width int) string {
+	filled := int(math.Round(progress.Ratio * float64(width)))
+	filled = min(max(filled, 0), width)
+
+	bar := progressFilledStyle.Render(strings.Repeat("█", filled)) +
+		progressEmptyStyle.Render(strings.Repeat("░", width-filled))
+
+	return fmt.Sprintf("%s %s", bar, progressEmptyStyle.Render(fmt.Sprintf("%d%% %d/%d", int(math.Round(progress.Ratio*100)), progress.Done, progress.Total)))
+}
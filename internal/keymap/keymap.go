@@ -0,0 +1,204 @@
+package keymap
+
+import (
+	"fmt"
+	"hinoki-cli/internal/config"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// Action is a remappable action of a screen, such as "timeframe.next_period"
+type Action struct {
+	id string
+}
+
+type action struct {
+	scope string
+	desc  string
+	keys  []string // Default keys, without the alternates of keyboard layouts
+}
+
+var (
+	registry = make(map[string]*action)
+
+	mu       sync.Mutex
+	settings *config.KeySettings // Loaded settings, nil until Load is called
+)
+
+// contexts lists the scopes whose keys are handled together on a screen or in a state of it.
+// A key may only be bound once within each context
+var contexts = map[string][]string{
+	"timeframe":  {"app", "list", "goals", "timeframe"},
+	"details":    {"app", "list", "screen", "goals", "details"},
+	"overdue":    {"app", "list", "screen", "goals", "overdue"},
+	"inbox":      {"app", "list", "screen", "goals", "inbox"},
+	"hierarchy":  {"app", "list", "screen", "hierarchy"},
+	"archive":    {"app", "list", "screen", "archive"},
+	"backups":    {"app", "list", "screen", "backups"},
+	"workspaces": {"app", "list", "screen", "workspaces"},
+	"calendar":   {"app", "screen", "calendar"},
+	"dashboard":  {"app", "list", "screen", "goals", "dashboard"},
+	"stats":      {"app", "screen", "stats"},
+	"review":     {"app", "screen", "review"},
+	"confirm":    {"app", "confirm"},
+	"search":     {"app", "screen", "search"},
+}
+
+// Register declares an action with its default keys. Actions are registered from package
+// level variables, so that Load can check the whole key map before any screen is opened
+func Register(scope, name, desc string, keys ...string) Action {
+	id := scope + "." + name
+	if _, ok := registry[id]; ok {
+		panic("keymap: action registered twice: " + id)
+	}
+
+	registry[id] = &action{scope: scope, desc: desc, keys: keys}
+	return Action{id: id}
+}
+
+// Load reads the key settings and checks them for unknown actions and layouts and for keys
+// bound to more than one action on the same screen. Without Load the default keys are used
+func Load() error {
+	loaded, err := config.GetKeySettings()
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+
+	for id := range loaded.Bindings {
+		if _, ok := registry[id]; !ok {
+			problems = append(problems, located("keys."+id, fmt.Sprintf("unknown action %s", id)))
+		}
+	}
+
+	for _, layout := range loaded.Layouts {
+		if _, ok := layouts[layout]; !ok {
+			problems = append(problems, located("keys.layouts", fmt.Sprintf("unknown keyboard layout %q, known layouts are %s", layout, strings.Join(layoutNames(), ", "))))
+		}
+	}
+
+	if len(problems) == 0 {
+		problems = conflicts(loaded)
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid key settings:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	mu.Lock()
+	settings = &loaded
+	mu.Unlock()
+
+	return nil
+}
+
+// located prefixes a problem with the line or environment variable the setting was read from
+func located(setting, problem string) string {
+	if origin := config.Origin(setting); origin != "" {
+		return origin + ": " + problem
+	}
+	return problem
+}
+
+// Binding returns the key binding of the action with the configured keys
+func (a Action) Binding() key.Binding {
+	mu.Lock()
+	current := settings
+	mu.Unlock()
+
+	if current == nil {
+		current = &config.KeySettings{Layouts: config.DefaultKeyboardLayouts}
+	}
+
+	primary := primaryKeys(a.id, *current)
+	if len(primary) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+
+	return key.NewBinding(
+		key.WithKeys(withLayouts(primary, current.Layouts)...),
+		key.WithHelp(helpKeys(primary), registry[a.id].desc),
+	)
+}
+
+// primaryKeys returns the configured keys of the action, or its default keys
+func primaryKeys(id string, s config.KeySettings) []string {
+	if keys, ok := s.Bindings[id]; ok {
+		return keys
+	}
+	return registry[id].keys
+}
+
+// conflicts returns a problem for every key bound to two actions in the same context
+func conflicts(s config.KeySettings) []string {
+	var problems []string
+	seen := make(map[string]bool)
+
+	for _, scopes := range contexts {
+		owners := make(map[string]string)
+
+		for _, id := range sortedActions(scopes) {
+			for _, k := range withLayouts(primaryKeys(id, s), s.Layouts) {
+				owner, taken := owners[k]
+				if !taken {
+					owners[k] = id
+					continue
+				}
+
+				problem := fmt.Sprintf("key %q is bound to both %s and %s", k, owner, id)
+				if !seen[problem] {
+					seen[problem] = true
+					problems = append(problems, problem)
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// sortedActions returns the IDs of the actions of the scopes in a stable order
+func sortedActions(scopes []string) []string {
+	var ids []string
+	for _, scope := range scopes {
+		for id, a := range registry {
+			if a.scope == scope {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// helpKeys describes keys for the help overlay, e.g. "↑ k"
+func helpKeys(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = keyName(k)
+	}
+	return strings.Join(names, " ")
+}
+
+func keyName(k string) string {
+	switch k {
+	case " ":
+		return "Spacebar"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "<-"
+	case "right":
+		return "->"
+	case "enter", "esc", "backspace", "delete", "tab":
+		return strings.ToUpper(k[:1]) + k[1:]
+	}
+	return k
+}
@@ -0,0 +1,52 @@
+package keymap
+
+import (
+	"hinoki-cli/internal/config"
+	"reflect"
+	"testing"
+)
+
+func TestWithLayouts(t *testing.T) {
+	got := withLayouts([]string{"d", "L", "#", "enter"}, []string{"ru"})
+	expected := []string{"d", "L", "#", "enter", "в", "Д", "№"}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("withLayouts = %q; want %q", got, expected)
+	}
+
+	if got := withLayouts([]string{"d"}, nil); !reflect.DeepEqual(got, []string{"d"}) {
+		t.Errorf("withLayouts without layouts = %q; want only the given keys", got)
+	}
+}
+
+func TestHelpKeys(t *testing.T) {
+	if got := helpKeys([]string{"up", "k"}); got != "↑ k" {
+		t.Errorf("helpKeys = %q; want %q", got, "↑ k")
+	}
+	if got := helpKeys([]string{" "}); got != "Spacebar" {
+		t.Errorf("helpKeys = %q; want %q", got, "Spacebar")
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	Register("goals", "test_create", "Create", "n")
+	Register("timeframe", "test_next", "Next", "l")
+	Register("hierarchy", "test_open", "Open", "n")
+
+	s := config.KeySettings{Layouts: []string{"ru"}}
+	if problems := conflicts(s); len(problems) != 0 {
+		t.Errorf("default keys conflict: %q", problems)
+	}
+
+	// Goal list keys are handled on the timeframe screen, hierarchy keys are not
+	s.Bindings = map[string][]string{"timeframe.test_next": {"n"}}
+	problems := conflicts(s)
+
+	expected := []string{
+		`key "n" is bound to both goals.test_create and timeframe.test_next`,
+		`key "т" is bound to both goals.test_create and timeframe.test_next`,
+	}
+	if !reflect.DeepEqual(problems, expected) {
+		t.Errorf("conflicts = %q; want %q", problems, expected)
+	}
+}
@@ -0,0 +1,62 @@
+package keymap
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// layouts maps the characters of a QWERTY keyboard to the characters typed by the same keys
+// in other layouts, so that bindings keep working without switching the layout
+var layouts = map[string]map[string]string{
+	"ru": layout(
+		"qwertyuiop[]asdfghjkl;'zxcvbnm,./`QWERTYUIOP{}ASDFGHJKL:\"ZXCVBNM<>?~#",
+		"йцукенгшщзхъфывапролджэячсмитьбю.ёЙЦУКЕНГШЩЗХЪФЫВАПРОЛДЖЭЯЧСМИТЬБЮ,Ё№",
+	),
+}
+
+func layout(qwerty, other string) map[string]string {
+	result := make(map[string]string)
+
+	for len(qwerty) > 0 && len(other) > 0 {
+		from, fromSize := utf8.DecodeRuneInString(qwerty)
+		to, toSize := utf8.DecodeRuneInString(other)
+		result[string(from)] = string(to)
+
+		qwerty, other = qwerty[fromSize:], other[toSize:]
+	}
+
+	return result
+}
+
+// withLayouts adds the keys typed in each layout by the keys of a QWERTY keyboard
+func withLayouts(keys []string, layoutNames []string) []string {
+	result := append([]string{}, keys...)
+
+	for _, name := range layoutNames {
+		for _, k := range keys {
+			if alternate, ok := layouts[name][k]; ok && !contains(result, alternate) {
+				result = append(result, alternate)
+			}
+		}
+	}
+
+	return result
+}
+
+func layoutNames() []string {
+	var names []string
+	for name := range layouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func contains(keys []string, k string) bool {
+	for _, existing := range keys {
+		if existing == k {
+			return true
+		}
+	}
+	return false
+}
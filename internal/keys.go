@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"hinoki-cli/internal/keymap"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type appKeyMap struct {
+	quit         key.Binding
+	quickCapture key.Binding
+	showHelp     key.Binding
+	toggleHints  key.Binding
+}
+
+var (
+	quitAction         = keymap.Register("app", "quit", "Quit", "ctrl+c")
+	quickCaptureAction = keymap.Register("app", "quick_capture", "Capture goal to inbox", "ctrl+n")
+	showHelpAction     = keymap.Register("app", "help", "Show all keys", "?", "f1")
+	toggleHintsAction  = keymap.Register("app", "hints", "Toggle key hints", "H")
+)
+
+func newAppKeyMap() appKeyMap {
+	return appKeyMap{
+		quit:         quitAction.Binding(),
+		quickCapture: quickCaptureAction.Binding(),
+		showHelp:     showHelpAction.Binding(),
+		toggleHints:  toggleHintsAction.Binding(),
+	}
+}
+
+// helpKeys returns the bindings that work on every screen
+func (k appKeyMap) helpKeys() []key.Binding {
+	return []key.Binding{k.showHelp, k.toggleHints, k.quickCapture, k.quit}
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"hinoki-cli/internal/config"
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/goal"
+	"time"
+)
+
+// GetArchivedGoals retrieves all archived goals, most recently archived first
+func GetArchivedGoals() ([]goal.Goal, error) {
+	query := `SELECT ` + goalColumns + goalsFrom + `
+		WHERE g.is_archived = 1
+		ORDER BY g.archived_at DESC, g.created_at DESC
+	`
+
+	rows, err := db.QueryDB(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanGoals(rows)
+}
+
+// RestoreGoal unarchives a goal. With subtree set, its archived descendants are restored too
+func RestoreGoal(goalID string, subtree bool) error {
+	return db.WithTransaction(func(tx *sql.Tx) error {
+		query := `SELECT id FROM goals WHERE id = ? AND is_archived = 1`
+		if subtree {
+			query = `
+				WITH RECURSIVE subtree(id, path) AS (
+					SELECT id, ',' || id || ','
+					FROM goals
+					WHERE id = ?
+					UNION ALL
+					SELECT child.id, st.path || child.id || ','
+					FROM subtree st
+					JOIN goals child ON child.parent_id = st.id
+					WHERE INSTR(st.path, ',' || child.id || ',') = 0
+				)
+				SELECT id FROM goals
+				WHERE is_archived = 1 AND id IN (SELECT id FROM subtree)
+			`
+		}
+
+		ids, err := queryIDs(tx, query, goalID)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			before, err := getGoalTx(tx, id)
+			if err != nil || before == nil {
+				return err
+			}
+
+			if _, err := tx.Exec(`UPDATE goals SET is_archived = 0, archived_at = NULL WHERE id = ?`, id); err != nil {
+				return err
+			}
+
+			after := *before
+			after.IsArchived = false
+			if err := recordEvents(tx, before, after); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteGoal permanently deletes an archived goal. Its subgoals are kept without a parent
+func DeleteGoal(goalID string) error {
+	return db.WithTransaction(func(tx *sql.Tx) error {
+		_, err := deleteArchivedGoals(tx, `id = ?`, goalID)
+		return err
+	})
+}
+
+// PurgeExpiredGoals permanently deletes goals archived longer than archive_retention_days
+// ago and returns how many were deleted. Without the setting nothing is deleted
+func PurgeExpiredGoals() (int, error) {
+	settings, err := config.GetArchiveSettings()
+	if err != nil || settings.RetentionDays == 0 {
+		return 0, err
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -settings.RetentionDays)
+
+	var purged int
+	err = db.WithTransaction(func(tx *sql.Tx) error {
+		purged, err = deleteArchivedGoals(tx, `datetime(archived_at) < datetime(?)`, cutoff.UTC().Format("2006-01-02 15:04:05"))
+		return err
+	})
+
+	return purged, err
+}
+
+// deleteArchivedGoals deletes the archived goals matching condition with their tags,
+// and detaches their children, which records the change in their history
+func deleteArchivedGoals(tx *sql.Tx, condition string, args ...interface{}) (int, error) {
+	ids, err := queryIDs(tx, `SELECT id FROM goals WHERE is_archived = 1 AND `+condition, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if _, err := unlinkChildren(tx, id); err != nil {
+			return 0, err
+		}
+		if err := deleteGoalRow(tx, id); err != nil {
+			return 0, fmt.Errorf("failed to delete goal %s: %w", id, err)
+		}
+	}
+
+	return len(ids), nil
+}
+
+// archivedAt returns when an imported goal was archived. Documents exported before the archive
+// time was recorded fall back to the time of the import, which starts the retention period anew,
+// since the last update can be long before the goal was archived
+func archivedAt(g goal.Goal) *time.Time {
+	if !g.IsArchived {
+		return nil
+	}
+	if g.ArchivedAt != nil {
+		return g.ArchivedAt
+	}
+	now := time.Now()
+	return &now
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"hinoki-cli/internal/goal"
+	"testing"
+)
+
+// lastEvent returns the most recent event of a goal
+func lastEvent(t *testing.T, goalID string) goal.Event {
+	t.Helper()
+
+	events, err := GetGoalEvents(goalID)
+	if err != nil || len(events) == 0 {
+		t.Fatalf("GetGoalEvents returned %v, %v", events, err)
+	}
+	return events[0]
+}
+
+func TestDeleteGoal_RecordsDetachedSubgoals(t *testing.T) {
+	parent := addGoal(t, "Renovate", nil)
+	child := addGoal(t, "Paint walls", &parent.ID)
+
+	parent.IsArchived = true
+	if err := UpdateGoal(parent); err != nil {
+		t.Fatalf("UpdateGoal returned %v", err)
+	}
+	if err := DeleteGoal(parent.ID); err != nil {
+		t.Fatalf("DeleteGoal returned %v", err)
+	}
+
+	if parentID := mustGet(t, child.ID).ParentId; parentID != nil {
+		t.Errorf("parent of subgoal = %s; want none", *parentID)
+	}
+
+	want := goal.Event{Kind: goal.Reparented, OldValue: "Renovate", NewValue: ""}
+	if e := lastEvent(t, child.ID); e.Kind != want.Kind || e.OldValue != want.OldValue || e.NewValue != want.NewValue {
+		t.Errorf("last event of subgoal = %s %q → %q; want %s %q → %q", e.Kind, e.OldValue, e.NewValue, want.Kind, want.OldValue, want.NewValue)
+	}
+}
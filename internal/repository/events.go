@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/goal"
+)
+
+// GetGoalEvents retrieves the history of a goal, most recent change first
+func GetGoalEvents(goalID string) ([]goal.Event, error) {
+	rows, err := db.QueryDB(`
+		SELECT id, goal_id, kind, COALESCE(old_value, ''), COALESCE(new_value, ''), created_at
+		FROM goal_events
+		WHERE goal_id = ?
+		ORDER BY created_at DESC, id DESC
+	`, goalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []goal.Event
+	for rows.Next() {
+		var e goal.Event
+		if err := rows.Scan(&e.ID, &e.GoalID, &e.Kind, &e.OldValue, &e.NewValue, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// GetStatusEvents retrieves the status changes of all goals by goal, oldest first
+func GetStatusEvents() (map[string][]goal.Event, error) {
+	rows, err := db.QueryDB(`
+		SELECT id, goal_id, kind, COALESCE(old_value, ''), COALESCE(new_value, ''), created_at
+		FROM goal_events
+		WHERE kind = ?
+		ORDER BY created_at ASC, id ASC
+	`, string(goal.StatusChanged))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make(map[string][]goal.Event)
+	for rows.Next() {
+		var e goal.Event
+		if err := rows.Scan(&e.ID, &e.GoalID, &e.Kind, &e.OldValue, &e.NewValue, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		events[e.GoalID] = append(events[e.GoalID], e)
+	}
+
+	return events, rows.Err()
+}
+
+// GetAllEvents retrieves the history of every goal, oldest change first
+func GetAllEvents() ([]goal.Event, error) {
+	rows, err := db.QueryDB(`
+		SELECT id, goal_id, kind, COALESCE(old_value, ''), COALESCE(new_value, ''), created_at
+		FROM goal_events
+		ORDER BY created_at ASC, id ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []goal.Event
+	for rows.Next() {
+		var e goal.Event
+		if err := rows.Scan(&e.ID, &e.GoalID, &e.Kind, &e.OldValue, &e.NewValue, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// importEvents adds imported events to the history of their goals. The IDs are given anew, and
+// events already in the history, such as those of a merge of an earlier export, are skipped.
+// datetime() stores the time in the format of CURRENT_TIMESTAMP, so that it compares equal
+func importEvents(tx *sql.Tx, events []goal.Event) error {
+	for _, e := range events {
+		_, err := tx.Exec(`
+			INSERT INTO goal_events (goal_id, kind, old_value, new_value, created_at)
+			SELECT ?, ?, ?, ?, datetime(?)
+			WHERE NOT EXISTS (
+				SELECT 1 FROM goal_events
+				WHERE goal_id = ? AND kind = ? AND COALESCE(old_value, '') = ? AND COALESCE(new_value, '') = ? AND created_at = datetime(?)
+			)
+		`, e.GoalID, e.Kind, e.OldValue, e.NewValue, e.CreatedAt, e.GoalID, e.Kind, e.OldValue, e.NewValue, e.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import %s event of goal %s: %w", e.Kind, e.GoalID, err)
+		}
+	}
+
+	return nil
+}
+
+// recordEvents adds the changes from before to after to the history of the goal
+func recordEvents(tx *sql.Tx, before *goal.Goal, after goal.Goal) error {
+	for _, e := range goal.Events(before, after) {
+		_, err := tx.Exec(`
+			INSERT INTO goal_events (goal_id, kind, old_value, new_value)
+			VALUES (?, ?, ?, ?)
+		`, e.GoalID, e.Kind, e.OldValue, e.NewValue)
+		if err != nil {
+			return fmt.Errorf("failed to record %s event of goal %s: %w", e.Kind, e.GoalID, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteGoalRow deletes a goal with its tags and history
+func deleteGoalRow(tx *sql.Tx, goalID string) error {
+	if _, err := tx.Exec("DELETE FROM goal_tags WHERE goal_id = ?", goalID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM goal_events WHERE goal_id = ?", goalID); err != nil {
+		return err
+	}
+	_, err := tx.Exec("DELETE FROM goals WHERE id = ?", goalID)
+	return err
+}
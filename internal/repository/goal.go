@@ -3,66 +3,95 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"hinoki-cli/internal/config"
 	"hinoki-cli/internal/dates"
 	"hinoki-cli/internal/db"
 	"hinoki-cli/internal/goal"
+	"sort"
 	"strings"
 	"time"
 )
 
-// GetGoalsByParent retrieves all goals that have the specified parent ID
-func GetGoalsByParent(parentId string) ([]goal.Goal, error) {
-	var rows *sql.Rows
-	var err error
-
-	baseQuery := `
-		SELECT id, parent_id, title, created_at, updated_at, is_done, timeframe, date
-		FROM goals
-	`
-
-	orderByQuery := `
-		ORDER BY is_done ASC, created_at ASC;
-	`
+// goalColumns are the columns read by scanGoal, selected from goalsFrom
+const goalColumns = `
+	g.id, g.parent_id, p.title, g.title, COALESCE(g.notes, ''), g.created_at, g.updated_at,
+	g.is_done, g.timeframe, g.date, COALESCE(g.is_archived, 0), g.archived_at,
+	(SELECT GROUP_CONCAT(t.name, ',') FROM goal_tags gt JOIN tags t ON t.id = gt.tag_id WHERE gt.goal_id = g.id),
+	g.series_id, g.series_period, COALESCE(s.rule, ''), COALESCE(g.postponed_count, 0)
+`
+
+// goalsFrom joins every goal with its parent so that the parent title can be shown,
+// and with its series while the series is still repeating
+const goalsFrom = `
+	FROM goals g
+	LEFT JOIN goals p ON g.parent_id = p.id
+	LEFT JOIN series s ON g.series_id = s.id AND s.stopped_at IS NULL
+`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
 
-	filterArchivedQuery := `AND is_archived IS NOT true`
+// scanGoal reads a row selected with goalColumns
+func scanGoal(row rowScanner) (goal.Goal, error) {
+	var g goal.Goal
+	var tags sql.NullString
 
-	composeQuery := func(query string) string {
-		return baseQuery + query + filterArchivedQuery + orderByQuery
+	err := row.Scan(&g.ID, &g.ParentId, &g.ParentTitle, &g.Title, &g.Notes, &g.CreatedAt, &g.UpdatedAt, &g.IsDone, &g.Timeframe, &g.Date, &g.IsArchived, &g.ArchivedAt, &tags, &g.SeriesID, &g.SeriesPeriod, &g.Recurrence, &g.Postponed)
+	if err != nil {
+		return g, err
 	}
 
-	rows, err = db.QueryDB(
-		composeQuery(`WHERE parent_id = ?`),
-		parentId,
-	)
-
-	if err != nil {
-		return nil, err
+	if tags.Valid && tags.String != "" {
+		g.Tags = strings.Split(tags.String, ",")
+		sort.Strings(g.Tags)
 	}
 
+	return g, nil
+}
+
+// scanGoals reads all rows selected with goalColumns and closes them
+func scanGoals(rows *sql.Rows) ([]goal.Goal, error) {
 	defer rows.Close()
 
 	var goals []goal.Goal
 
 	for rows.Next() {
-		var goal goal.Goal
-		if err := rows.Scan(&goal.ID, &goal.ParentId, &goal.Title, &goal.CreatedAt, &goal.UpdatedAt, &goal.IsDone, &goal.Timeframe, &goal.Date); err != nil {
+		g, err := scanGoal(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scan failed: %w", err)
 		}
-		goals = append(goals, goal)
+		goals = append(goals, g)
 	}
+
 	return goals, rows.Err()
 }
 
+// GetGoalsByParent retrieves all goals that have the specified parent ID
+func GetGoalsByParent(parentId string) ([]goal.Goal, error) {
+	query := `SELECT ` + goalColumns + goalsFrom + `
+		WHERE g.parent_id = ? AND g.is_archived IS NOT true
+		ORDER BY g.is_done ASC, g.created_at ASC;
+	`
+
+	rows, err := db.QueryDB(query, parentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanGoals(rows)
+}
+
 // GetGoalsByDate retrieves all goals for a specific timeframe and date
 func GetGoalsByDate(timeframe goal.Timeframe, date time.Time) ([]goal.Goal, error) {
 	var rows *sql.Rows
 	var err error
 
-	baseQuery := `
-		SELECT g.id, g.title, g.created_at, g.updated_at, g.is_done, g.timeframe, g.date, p.id, p.title
-		FROM goals g
-		LEFT JOIN goals p ON g.parent_id = p.id
-	`
+	if err := generateSeriesGoals(timeframe, date); err != nil {
+		return nil, fmt.Errorf("failed to generate recurring goals: %w", err)
+	}
+
+	baseQuery := `SELECT ` + goalColumns + goalsFrom
 	orderByQuery := `
 		ORDER BY g.is_done ASC, g.created_at ASC;
 	`
@@ -73,6 +102,7 @@ func GetGoalsByDate(timeframe goal.Timeframe, date time.Time) ([]goal.Goal, erro
 		return baseQuery + query + filterArchivedQuery + orderByQuery
 	}
 
+	// Periods are ranges of DATE(g.date), so that the timeframe and date index is used
 	switch timeframe {
 	case goal.Day:
 		rows, err = db.QueryDB(
@@ -88,10 +118,12 @@ func GetGoalsByDate(timeframe goal.Timeframe, date time.Time) ([]goal.Goal, erro
 			dates.TimeframeDateString(dates.EndOfWeek(date)),
 		)
 	case goal.Month:
+		month := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
 		rows, err = db.QueryDB(
-			composeQuery(`WHERE g.timeframe = ? AND DATE(g.date) LIKE ?`),
+			composeQuery(`WHERE g.timeframe = ? AND DATE(g.date) >= ? AND DATE(g.date) < ?`),
 			string(timeframe),
-			date.Format("2006-01%"),
+			dates.TimeframeDateString(month),
+			dates.TimeframeDateString(month.AddDate(0, 1, 0)),
 		)
 	case goal.Quarter:
 		rows, err = db.QueryDB(
@@ -101,10 +133,12 @@ func GetGoalsByDate(timeframe goal.Timeframe, date time.Time) ([]goal.Goal, erro
 			dates.TimeframeDateString(dates.EndOfQuarter(date)),
 		)
 	case goal.Year:
+		year := time.Date(date.Year(), 1, 1, 0, 0, 0, 0, date.Location())
 		rows, err = db.QueryDB(
-			composeQuery(`WHERE g.timeframe = ? AND DATE(g.date) LIKE ?`),
+			composeQuery(`WHERE g.timeframe = ? AND DATE(g.date) >= ? AND DATE(g.date) < ?`),
 			string(timeframe),
-			date.Format("2006%"),
+			dates.TimeframeDateString(year),
+			dates.TimeframeDateString(year.AddDate(1, 0, 0)),
 		)
 	case goal.Life:
 		rows, err = db.QueryDB(
@@ -117,34 +151,68 @@ func GetGoalsByDate(timeframe goal.Timeframe, date time.Time) ([]goal.Goal, erro
 		return nil, err
 	}
 
-	defer rows.Close()
+	return scanGoals(rows)
+}
 
-	var goals []goal.Goal
+// DayCount is how many goals of a day there are and how many of them are done
+type DayCount struct {
+	Total int
+	Done  int
+}
 
-	for rows.Next() {
-		var goal goal.Goal
+// GetDayGoalCounts counts the day goals of every day from start to end, by date as written by
+// dates.TimeframeDateString. Days without goals are left out. Recurring goals are only counted
+// once they have been created by opening their day
+func GetDayGoalCounts(start, end time.Time) (map[string]DayCount, error) {
+	rows, err := db.QueryDB(`
+		SELECT DATE(g.date), COUNT(*), SUM(g.is_done)
+		FROM goals g
+		WHERE g.timeframe = ? AND g.is_archived IS NOT true
+		AND DATE(g.date) >= ? AND DATE(g.date) <= ?
+		GROUP BY DATE(g.date)
+	`, string(goal.Day), dates.TimeframeDateString(start), dates.TimeframeDateString(end))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-		if err := rows.Scan(&goal.ID, &goal.Title, &goal.CreatedAt, &goal.UpdatedAt, &goal.IsDone, &goal.Timeframe, &goal.Date, &goal.ParentId, &goal.ParentTitle); err != nil {
+	counts := make(map[string]DayCount)
+	for rows.Next() {
+		var day string
+		var count DayCount
+		if err := rows.Scan(&day, &count.Total, &count.Done); err != nil {
 			return nil, fmt.Errorf("scan failed: %w", err)
 		}
+		counts[day] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetUnscheduledGoals retrieves all goals that have no period to be listed in,
+// such as subgoals created from goal details or goals captured to the inbox
+func GetUnscheduledGoals() ([]goal.Goal, error) {
+	query := `SELECT ` + goalColumns + goalsFrom + `
+		WHERE g.is_archived IS NOT true
+		AND (g.timeframe IS NULL OR (g.date IS NULL AND g.timeframe != 'life'))
+		ORDER BY g.is_done ASC, g.created_at ASC;
+	`
 
-		goals = append(goals, goal)
+	rows, err := db.QueryDB(query)
+	if err != nil {
+		return nil, err
 	}
-	return goals, rows.Err()
+
+	return scanGoals(rows)
 }
 
 // GetGoalByID retrieves a single goal by its ID
 func GetGoalByID(goalID string) (*goal.Goal, error) {
-	query := `
-		SELECT id, parent_id, title, created_at, updated_at, is_done, timeframe, date, COALESCE(is_archived, 0) as is_archived
-		FROM goals
-		WHERE id = ? AND COALESCE(is_archived, 0) = 0
+	query := `SELECT ` + goalColumns + goalsFrom + `
+		WHERE g.id = ? AND COALESCE(g.is_archived, 0) = 0
 	`
 
-	row := db.QueryRowDB(query, goalID)
-
-	var g goal.Goal
-	err := row.Scan(&g.ID, &g.ParentId, &g.Title, &g.CreatedAt, &g.UpdatedAt, &g.IsDone, &g.Timeframe, &g.Date, &g.IsArchived)
+	g, err := scanGoal(db.QueryRowDB(query, goalID))
 	if err != nil {
 		return nil, err
 	}
@@ -152,89 +220,248 @@ func GetGoalByID(goalID string) (*goal.Goal, error) {
 	return &g, nil
 }
 
-// AddGoal creates a new goal in the database
+// AddGoal creates a new goal in the database and records its creation. The goal can be removed again with Undo
 func AddGoal(goal goal.Goal) error {
-	_, err := db.ExecQuery("INSERT INTO goals (id, parent_id, title, is_done, timeframe, date) VALUES (?, ?, ?, ?, ?, ?)", goal.ID, goal.ParentId, goal.Title, goal.IsDone, goal.Timeframe, goal.Date)
+	var entry historyEntry
 
-	return err
+	err := db.WithTransaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec("INSERT INTO goals (id, parent_id, title, notes, is_done, timeframe, date) VALUES (?, ?, ?, ?, ?, ?, ?)", goal.ID, goal.ParentId, goal.Title, goal.Notes, goal.IsDone, goal.Timeframe, goal.Date)
+		if err != nil {
+			return err
+		}
+
+		if err := setGoalTags(tx, goal.ID, goal.Tags); err != nil {
+			return err
+		}
+
+		after, err := getGoalTx(tx, goal.ID)
+		if err != nil {
+			return err
+		}
+		entry.goals = []goalChange{{after: after}}
+
+		return recordEvents(tx, nil, *after)
+	})
+	if err != nil {
+		return err
+	}
+
+	recordChange(entry)
+	return nil
 }
 
-// UpdateGoal updates an existing goal in the database
+// UpdateGoal updates an existing goal in the database, replacing its tags with goal.Tags,
+// and records the changes in the goal history. With auto_complete_parents set, finishing the last undone child also marks its parents done
+// The change, including completed parents, can be reverted with Undo
 func UpdateGoal(goal goal.Goal) error {
-	_, err := db.ExecQuery("UPDATE goals SET title = ?, is_done = ?, timeframe = ?, date = ?, is_archived = ?, parent_id = ? WHERE id = ?", goal.Title, goal.IsDone, goal.Timeframe, goal.Date, goal.IsArchived, goal.ParentId, goal.ID)
+	settings, _ := config.GetProgressSettings()
 
-	return err
+	var entry historyEntry
+
+	err := db.WithTransaction(func(tx *sql.Tx) error {
+		var err error
+		entry.goals, err = updateGoalTx(tx, goal, settings)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if !entry.isEmpty() {
+		recordChange(entry)
+	}
+	return nil
 }
 
-// SearchGoals searches for goals matching the given search term
-func SearchGoals(term string, limit int) ([]goal.Goal, error) {
+// updateGoalTx updates a goal and the parents it completes, and returns the changes for the history
+func updateGoalTx(tx *sql.Tx, goal goal.Goal, settings config.ProgressSettings) ([]goalChange, error) {
+	before, err := getGoalTx(tx, goal.ID)
+	if err != nil || before == nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE goals
+		SET title = ?, notes = ?, is_done = ?, timeframe = ?, date = ?, is_archived = ?, parent_id = ?,
+			archived_at = CASE WHEN ? THEN COALESCE(archived_at, CURRENT_TIMESTAMP) END
+		WHERE id = ?
+	`, goal.Title, goal.Notes, goal.IsDone, goal.Timeframe, goal.Date, goal.IsArchived, goal.ParentId, goal.IsArchived, goal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setGoalTags(tx, goal.ID, goal.Tags); err != nil {
+		return nil, err
+	}
+
+	var completed []string
+	if settings.AutoCompleteParents && goal.IsDone && goal.ParentId != nil {
+		if completed, err = completeParents(tx, *goal.ParentId); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []goalChange
+	for _, id := range append([]string{goal.ID}, completed...) {
+		after, err := getGoalTx(tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		change := goalChange{before: before, after: after}
+		if id != goal.ID {
+			// Completed parents were undone before the change
+			parentBefore := *after
+			parentBefore.IsDone = false
+			change.before = &parentBefore
+		}
+		changes = append(changes, change)
+
+		if err := recordEvents(tx, change.before, *change.after); err != nil {
+			return nil, err
+		}
+	}
+
+	return changes, nil
+}
+
+// setGoalTags replaces the tags of a goal, creating tags that don't exist yet
+func setGoalTags(tx *sql.Tx, goalID string, tags []string) error {
+	if _, err := tx.Exec("DELETE FROM goal_tags WHERE goal_id = ?", goalID); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if _, err := tx.Exec("INSERT OR IGNORE INTO tags (name) VALUES (?)", tag); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec("INSERT OR IGNORE INTO goal_tags (goal_id, tag_id) SELECT ?, id FROM tags WHERE name = ?", goalID, tag)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SearchGoals searches for goals whose title or notes match the given search term
+// If tags are given, only goals with at least one of them are returned and the term may be empty
+func SearchGoals(term string, tags []string, limit int) ([]goal.Goal, error) {
 	if limit <= 0 {
 		limit = 20
 	}
 
 	trimmed := strings.TrimSpace(term)
-	if trimmed == "" {
+	if trimmed == "" && len(tags) == 0 {
 		return []goal.Goal{}, nil
 	}
 
-	query := `
-		SELECT g.id, g.title, g.created_at, g.updated_at, g.is_done, g.timeframe, g.date, g.parent_id, p.title
-		FROM goals g
-		LEFT JOIN goals p ON g.parent_id = p.id
-		WHERE g.is_archived IS NOT true AND LOWER(g.title) LIKE LOWER(?)
-		ORDER BY g.date IS NULL, g.date DESC, g.updated_at DESC
+	pattern := "%" + trimmed + "%"
+
+	query := `SELECT ` + goalColumns + goalsFrom + `
+		WHERE g.is_archived IS NOT true
+		AND (LOWER(g.title) LIKE LOWER(?) OR LOWER(COALESCE(g.notes, '')) LIKE LOWER(?))
+	`
+	args := []interface{}{pattern, pattern}
+
+	if len(tags) > 0 {
+		query += `AND ` + hasAnyTagQuery(len(tags))
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
+	}
+
+	query += `
+		ORDER BY LOWER(g.title) LIKE LOWER(?) DESC, g.date IS NULL, g.date DESC, g.updated_at DESC
 		LIMIT ?
 	`
+	args = append(args, pattern, limit)
 
-	rows, err := db.QueryDB(query, "%"+trimmed+"%", limit)
+	rows, err := db.QueryDB(query, args...)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var goals []goal.Goal
 
-	for rows.Next() {
-		var g goal.Goal
-		if err := rows.Scan(&g.ID, &g.Title, &g.CreatedAt, &g.UpdatedAt, &g.IsDone, &g.Timeframe, &g.Date, &g.ParentId, &g.ParentTitle); err != nil {
-			return nil, fmt.Errorf("scan failed: %w", err)
-		}
-		goals = append(goals, g)
-	}
+	return scanGoals(rows)
+}
 
-	return goals, rows.Err()
+// hasAnyTagQuery returns a condition on g matching goals with any of count tags, passed as arguments
+func hasAnyTagQuery(count int) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", count), ", ")
+	return `EXISTS (
+		SELECT 1 FROM goal_tags gt JOIN tags t ON t.id = gt.tag_id
+		WHERE gt.goal_id = g.id AND t.name IN (` + placeholders + `)
+	)`
 }
 
 // GetAncestorChain retrieves all ancestors of a goal, from the goal itself up to the root parent
 // Returns goals in order from root (topmost parent) to the goal itself
+// The chain stops at archived goals and at the first goal that would repeat
 func GetAncestorChain(goalID string) ([]goal.Goal, error) {
-	var chain []goal.Goal
-	currentID := goalID
-	visited := make(map[string]bool) // Prevent infinite loops
-
-	for currentID != "" {
-		// Prevent infinite loops
-		if visited[currentID] {
-			break
-		}
-		visited[currentID] = true
+	query := `
+		WITH RECURSIVE chain(id, depth, path) AS (
+			SELECT id, 0, ',' || id || ','
+			FROM goals
+			WHERE id = ? AND is_archived IS NOT true
+			UNION ALL
+			SELECT parent.id, c.depth + 1, c.path || parent.id || ','
+			FROM chain c
+			JOIN goals child ON child.id = c.id
+			JOIN goals parent ON parent.id = child.parent_id
+			WHERE parent.is_archived IS NOT true AND INSTR(c.path, ',' || parent.id || ',') = 0
+		)
+		SELECT ` + goalColumns + goalsFrom + `
+		JOIN chain c ON c.id = g.id
+		ORDER BY c.depth DESC
+	`
 
-		g, err := GetGoalByID(currentID)
-		if err != nil || g == nil {
-			break
-		}
+	rows, err := db.QueryDB(query, goalID)
+	if err != nil {
+		return nil, err
+	}
 
-		// Prepend to chain (we want root first, goal last)
-		chain = append([]goal.Goal{*g}, chain...)
+	return scanGoals(rows)
+}
 
-		// Move to parent
-		if g.ParentId == nil || *g.ParentId == "" {
-			break
-		}
-		currentID = *g.ParentId
+// GetAncestors retrieves the ancestors of a goal, from the root parent down to its direct parent
+func GetAncestors(goalID string) ([]goal.Goal, error) {
+	chain, err := GetAncestorChain(goalID)
+	if err != nil || len(chain) == 0 {
+		return nil, err
+	}
+
+	// The chain ends with the goal itself
+	return chain[:len(chain)-1], nil
+}
+
+// GetSubtree retrieves all descendants of a goal down to maxDepth levels, or all levels if
+// maxDepth is 0. Goals are ordered by depth, and like GetGoalsByParent among siblings
+func GetSubtree(goalID string, maxDepth int) ([]goal.Goal, error) {
+	query := `
+		WITH RECURSIVE subtree(id, depth, path) AS (
+			SELECT id, 1, ',' || parent_id || ',' || id || ','
+			FROM goals
+			WHERE parent_id = ? AND is_archived IS NOT true
+			UNION ALL
+			SELECT child.id, st.depth + 1, st.path || child.id || ','
+			FROM subtree st
+			JOIN goals child ON child.parent_id = st.id
+			WHERE child.is_archived IS NOT true
+			AND INSTR(st.path, ',' || child.id || ',') = 0
+			AND (? <= 0 OR st.depth < ?)
+		)
+		SELECT ` + goalColumns + goalsFrom + `
+		JOIN subtree st ON st.id = g.id
+		ORDER BY st.depth ASC, g.is_done ASC, g.created_at ASC
+	`
+
+	rows, err := db.QueryDB(query, goalID, maxDepth, maxDepth)
+	if err != nil {
+		return nil, err
 	}
 
-	return chain, nil
+	return scanGoals(rows)
 }
 
 // GetOverdueGoals retrieves all undone goals that are overdue
@@ -242,11 +469,8 @@ func GetAncestorChain(goalID string) ([]goal.Goal, error) {
 func GetOverdueGoals() ([]goal.Goal, error) {
 	today := dates.DateWithoutTime(time.Now())
 
-	baseQuery := `
-		SELECT g.id, g.title, g.created_at, g.updated_at, g.is_done, g.timeframe, g.date, g.parent_id, p.title
-		FROM goals g
-		LEFT JOIN goals p ON g.parent_id = p.id
-		WHERE g.is_archived IS NOT true 
+	baseQuery := `SELECT ` + goalColumns + goalsFrom + `
+		WHERE g.is_archived IS NOT true
 		AND g.is_done = 0
 		AND g.timeframe IS NOT NULL
 		AND g.date IS NOT NULL
@@ -268,16 +492,15 @@ func GetOverdueGoals() ([]goal.Goal, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var goals []goal.Goal
+	candidates, err := scanGoals(rows)
+	if err != nil {
+		return nil, err
+	}
 
-	for rows.Next() {
-		var g goal.Goal
-		if err := rows.Scan(&g.ID, &g.Title, &g.CreatedAt, &g.UpdatedAt, &g.IsDone, &g.Timeframe, &g.Date, &g.ParentId, &g.ParentTitle); err != nil {
-			return nil, fmt.Errorf("scan failed: %w", err)
-		}
+	var goals []goal.Goal
 
+	for _, g := range candidates {
 		// Additional check: filter out goals that aren't actually overdue
 		// This handles edge cases for week/month/quarter/year timeframes
 		if dates.IsOverdue(g.Date, g.Timeframe) {
@@ -285,5 +508,173 @@ func GetOverdueGoals() ([]goal.Goal, error) {
 		}
 	}
 
-	return goals, rows.Err()
+	return goals, nil
+}
+
+// GetGoalByIDPrefix retrieves a single goal whose ID starts with the given prefix, ignoring case.
+// The prefix is compared as is, so % and _ are no wildcards
+// Returns an error if no goal or more than one goal matches
+func GetGoalByIDPrefix(prefix string) (*goal.Goal, error) {
+	trimmed := strings.TrimSpace(prefix)
+	if trimmed == "" {
+		return nil, fmt.Errorf("empty goal id")
+	}
+
+	rows, err := db.QueryDB(`
+		SELECT id
+		FROM goals
+		WHERE LOWER(substr(id, 1, length(?))) = LOWER(?) AND COALESCE(is_archived, 0) = 0
+		LIMIT 2
+	`, trimmed, trimmed)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	switch len(ids) {
+	case 0:
+		return nil, fmt.Errorf("no goal matches id %q", trimmed)
+	case 1:
+		return GetGoalByID(ids[0])
+	default:
+		return nil, fmt.Errorf("id %q is ambiguous", trimmed)
+	}
+}
+
+// GetAllGoals retrieves every goal including archived ones, parents first where possible
+func GetAllGoals() ([]goal.Goal, error) {
+	query := `SELECT ` + goalColumns + goalsFrom + `
+		ORDER BY g.parent_id IS NOT NULL, g.created_at ASC, g.id ASC
+	`
+
+	rows, err := db.QueryDB(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanGoals(rows)
+}
+
+// Snapshot is the content of a database that is exported and imported
+type Snapshot struct {
+	Goals       []goal.Goal
+	Series      []Series
+	Events      []goal.Event
+	PeriodNotes []PeriodNote
+}
+
+// ImportSnapshot writes the goals, series, goal history and period notes of a snapshot in a single
+// transaction, keeping their IDs and timestamps. Existing goals and series with the same ID, and
+// notes of the same period, are updated. If replace is true, all others are deleted first
+func ImportSnapshot(snapshot Snapshot, replace bool) error {
+	return db.WithTransaction(func(tx *sql.Tx) error {
+		if replace {
+			if _, err := tx.Exec("DELETE FROM goal_tags"); err != nil {
+				return err
+			}
+			if _, err := tx.Exec("DELETE FROM goals"); err != nil {
+				return err
+			}
+			if _, err := tx.Exec("DELETE FROM series"); err != nil {
+				return err
+			}
+			if _, err := tx.Exec("DELETE FROM goal_events"); err != nil {
+				return err
+			}
+			if _, err := tx.Exec("DELETE FROM period_notes"); err != nil {
+				return err
+			}
+		}
+
+		for _, s := range snapshot.Series {
+			_, err := tx.Exec(`
+				INSERT INTO series (id, title, tags, parent_id, rule, start_period, stopped_at, created_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT(id) DO UPDATE SET
+					title = excluded.title,
+					tags = excluded.tags,
+					parent_id = excluded.parent_id,
+					rule = excluded.rule,
+					start_period = excluded.start_period,
+					stopped_at = excluded.stopped_at,
+					created_at = excluded.created_at
+			`, s.ID, s.Title, strings.Join(s.Tags, ","), s.ParentID, s.Rule, s.StartPeriod, s.StoppedAt, s.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to import series %s: %w", s.ID, err)
+			}
+		}
+
+		stmt, err := tx.Prepare(`
+			INSERT INTO goals (id, parent_id, title, notes, created_at, updated_at, is_done, timeframe, date, is_archived, archived_at, series_id, series_period, postponed_count)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(id) DO UPDATE SET
+				parent_id = excluded.parent_id,
+				title = excluded.title,
+				notes = excluded.notes,
+				created_at = excluded.created_at,
+				updated_at = excluded.updated_at,
+				is_done = excluded.is_done,
+				timeframe = excluded.timeframe,
+				date = excluded.date,
+				is_archived = excluded.is_archived,
+				archived_at = excluded.archived_at,
+				series_id = excluded.series_id,
+				series_period = excluded.series_period,
+				postponed_count = excluded.postponed_count
+		`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		err = keepUpdatedAt(tx, func() error {
+			for _, g := range snapshot.Goals {
+				if _, err := stmt.Exec(g.ID, g.ParentId, g.Title, g.Notes, g.CreatedAt, g.UpdatedAt, g.IsDone, g.Timeframe, g.Date, g.IsArchived, archivedAt(g), g.SeriesID, g.SeriesPeriod, g.Postponed); err != nil {
+					return fmt.Errorf("failed to import goal %s: %w", g.ID, err)
+				}
+
+				if err := setGoalTags(tx, g.ID, g.Tags); err != nil {
+					return fmt.Errorf("failed to import tags of goal %s: %w", g.ID, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := importEvents(tx, snapshot.Events); err != nil {
+			return err
+		}
+
+		return importPeriodNotes(tx, snapshot.PeriodNotes)
+	})
+}
+
+// keepUpdatedAt runs fn without the goals_updated_at trigger, so the goals fn writes keep the
+// updated_at they are written with, as imports and restores need. The flag is removed again
+// before the transaction commits, so no other change ever sees it
+func keepUpdatedAt(tx *sql.Tx, fn func() error) error {
+	if _, err := tx.Exec("INSERT INTO keep_updated_at (flag) VALUES (1)"); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec("DELETE FROM keep_updated_at")
+	return err
 }
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/goal"
+	"strings"
+	"sync"
+)
+
+// historyLimit is how many changes can be undone
+const historyLimit = 100
+
+// goalChange is the state of a goal before and after a change. before is nil for goals
+// the change created
+type goalChange struct {
+	before *goal.Goal
+	after  *goal.Goal
+	// children are the subgoals unlinked when undo deleted the created goal, linked again by redo
+	children []string
+}
+
+// seriesChange is the state of a series before and after a change
+type seriesChange struct {
+	before Series
+	after  Series
+}
+
+// historyEntry holds the goals and series changed by a single AddGoal, UpdateGoal,
+// UpdateSeriesGoal or RollForward call, such as a goal marked done together with the parents
+// it completed, or a repeating goal renamed together with its series
+type historyEntry struct {
+	goals  []goalChange
+	series []seriesChange
+}
+
+func (e historyEntry) isEmpty() bool {
+	return len(e.goals) == 0 && len(e.series) == 0
+}
+
+// history records the changes of the session, so they can be undone and redone
+var history struct {
+	sync.Mutex
+	undo []historyEntry
+	redo []historyEntry
+}
+
+// recordChange adds a change to the history. A new change can't be redone over
+func recordChange(entry historyEntry) {
+	history.Lock()
+	defer history.Unlock()
+
+	history.undo = append(history.undo, entry)
+	if len(history.undo) > historyLimit {
+		history.undo = history.undo[len(history.undo)-historyLimit:]
+	}
+	history.redo = nil
+}
+
+// ClearHistory forgets the changes of the session, e.g. once another database is open
+func ClearHistory() {
+	history.Lock()
+	defer history.Unlock()
+
+	history.undo = nil
+	history.redo = nil
+}
+
+// Undo reverts the last recorded goal change. Returns false if there is nothing to undo
+func Undo() (bool, error) {
+	return moveHistory(&history.undo, &history.redo, true)
+}
+
+// Redo applies the last undone goal change again. Returns false if there is nothing to redo
+func Redo() (bool, error) {
+	return moveHistory(&history.redo, &history.undo, false)
+}
+
+// moveHistory applies the last entry of from and moves it to to. A failed entry is dropped,
+// since the goals it changed were most likely deleted in the meantime
+func moveHistory(from, to *[]historyEntry, undo bool) (bool, error) {
+	history.Lock()
+	defer history.Unlock()
+
+	if len(*from) == 0 {
+		return false, nil
+	}
+
+	entry := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+
+	err := db.WithTransaction(func(tx *sql.Tx) error {
+		for i := range entry.goals {
+			change := &entry.goals[i]
+			if undo {
+				// Goals are restored in reverse order, parents completed last are reverted first
+				change = &entry.goals[len(entry.goals)-1-i]
+			}
+
+			target := change.after
+			if undo {
+				target = change.before
+			}
+
+			if err := restoreGoalState(tx, change, target); err != nil {
+				return err
+			}
+		}
+
+		for _, change := range entry.series {
+			target := change.after
+			if undo {
+				target = change.before
+			}
+
+			if err := restoreSeriesState(tx, target); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return true, err
+	}
+
+	*to = append(*to, entry)
+	return true, nil
+}
+
+// restoreGoalState writes the state of a goal recorded in the history, every column read by
+// goalColumns, and records the events of the restore. A nil state deletes the goal, a goal that
+// no longer exists is created again, in its series if it was generated by one
+func restoreGoalState(tx *sql.Tx, change *goalChange, state *goal.Goal) error {
+	if state == nil {
+		id := change.after.ID
+		children, err := unlinkChildren(tx, id)
+		if err != nil {
+			return err
+		}
+		change.children = children
+		return deleteGoalRow(tx, id)
+	}
+
+	current, err := getGoalTx(tx, state.ID)
+	if err != nil {
+		return err
+	}
+
+	err = keepUpdatedAt(tx, func() error {
+		_, err := tx.Exec(`
+			INSERT INTO goals (id, parent_id, title, notes, created_at, updated_at, is_done, timeframe, date, is_archived, archived_at, series_id, series_period, postponed_count)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(id) DO UPDATE SET
+				parent_id = excluded.parent_id,
+				title = excluded.title,
+				notes = excluded.notes,
+				created_at = excluded.created_at,
+				updated_at = excluded.updated_at,
+				is_done = excluded.is_done,
+				timeframe = excluded.timeframe,
+				date = excluded.date,
+				is_archived = excluded.is_archived,
+				archived_at = excluded.archived_at,
+				series_id = excluded.series_id,
+				series_period = excluded.series_period,
+				postponed_count = excluded.postponed_count
+		`, state.ID, state.ParentId, state.Title, state.Notes, state.CreatedAt, state.UpdatedAt, state.IsDone, state.Timeframe, state.Date, state.IsArchived, state.ArchivedAt, state.SeriesID, state.SeriesPeriod, state.Postponed)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore goal %s: %w", state.ID, err)
+	}
+
+	if err := setGoalTags(tx, state.ID, state.Tags); err != nil {
+		return err
+	}
+
+	restored, err := getGoalTx(tx, state.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := recordEvents(tx, current, *restored); err != nil {
+		return err
+	}
+
+	// Redo creates the goal again, so the subgoals its undo unlinked belong to it again
+	for _, childID := range change.children {
+		if err := linkChild(tx, childID, state.ID); err != nil {
+			return err
+		}
+	}
+	change.children = nil
+
+	return nil
+}
+
+// unlinkChildren detaches the subgoals of a goal, recording the change in their history,
+// and returns their IDs
+func unlinkChildren(tx *sql.Tx, parentID string) ([]string, error) {
+	ids, err := queryIDs(tx, "SELECT id FROM goals WHERE parent_id = ?", parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if err := setParent(tx, id, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// linkChild makes a goal a subgoal of parentID again, unless it was deleted since
+func linkChild(tx *sql.Tx, childID, parentID string) error {
+	return setParent(tx, childID, &parentID)
+}
+
+// setParent moves a goal under parentID, or to the top level if it is nil, and records the
+// change in the history of the goal. Goals that no longer exist are skipped
+func setParent(tx *sql.Tx, goalID string, parentID *string) error {
+	before, err := getGoalTx(tx, goalID)
+	if err != nil || before == nil {
+		return err
+	}
+
+	if _, err := tx.Exec("UPDATE goals SET parent_id = ? WHERE id = ?", parentID, goalID); err != nil {
+		return err
+	}
+
+	after, err := getGoalTx(tx, goalID)
+	if err != nil {
+		return err
+	}
+	return recordEvents(tx, before, *after)
+}
+
+// getGoalTx reads a goal inside a transaction, including archived goals
+func getGoalTx(tx *sql.Tx, goalID string) (*goal.Goal, error) {
+	g, err := scanGoal(tx.QueryRow(`SELECT `+goalColumns+goalsFrom+` WHERE g.id = ?`, goalID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+// restoreSeriesState writes the title and tags of a series recorded in the history
+func restoreSeriesState(tx *sql.Tx, state Series) error {
+	_, err := tx.Exec("UPDATE series SET title = ?, tags = ? WHERE id = ?", state.Title, strings.Join(state.Tags, ","), state.ID)
+	if err != nil {
+		return fmt.Errorf("failed to restore series %s: %w", state.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"database/sql"
+	"hinoki-cli/internal/config"
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/goal"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestMain runs the tests against a database and config file of their own
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "hinoki-repository")
+	if err != nil {
+		panic(err)
+	}
+
+	os.Setenv("HINOKI_CONFIG", filepath.Join(dir, "config.ini"))
+	if err := config.Set("backup.dir", filepath.Join(dir, "backups")); err != nil {
+		panic(err)
+	}
+	db.SetPath(filepath.Join(dir, "hinoki.db"))
+	db.InitDB()
+
+	code := m.Run()
+
+	db.CloseDB()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// addGoal creates a day goal for today, starting from an empty history
+func addGoal(t *testing.T, title string, parentID *string) goal.Goal {
+	t.Helper()
+
+	today := time.Now()
+	timeframe := goal.Day
+	g := goal.Goal{ID: uuid.New().String(), Title: title, ParentId: parentID, Timeframe: &timeframe, Date: &today}
+	if err := AddGoal(g); err != nil {
+		t.Fatalf("AddGoal returned %v", err)
+	}
+	return g
+}
+
+// mustGet reads a goal, failing the test if it doesn't exist
+func mustGet(t *testing.T, id string) *goal.Goal {
+	t.Helper()
+
+	g, err := getGoal(id)
+	if err != nil {
+		t.Fatalf("getGoal returned %v", err)
+	}
+	if g == nil {
+		t.Fatalf("goal %s doesn't exist", id)
+	}
+	return g
+}
+
+// getGoal reads a goal including archived ones, nil if it doesn't exist
+func getGoal(id string) (*goal.Goal, error) {
+	var g *goal.Goal
+	err := db.WithTransaction(func(tx *sql.Tx) error {
+		var err error
+		g, err = getGoalTx(tx, id)
+		return err
+	})
+	return g, err
+}
+
+func mustUndo(t *testing.T) {
+	t.Helper()
+	if ok, err := Undo(); !ok || err != nil {
+		t.Fatalf("Undo returned %v, %v; want true, nil", ok, err)
+	}
+}
+
+func mustRedo(t *testing.T) {
+	t.Helper()
+	if ok, err := Redo(); !ok || err != nil {
+		t.Fatalf("Redo returned %v, %v; want true, nil", ok, err)
+	}
+}
+
+func TestUndoRedo_Add(t *testing.T) {
+	ClearHistory()
+	g := addGoal(t, "Write report", nil)
+
+	mustUndo(t)
+	if deleted, err := getGoal(g.ID); err != nil || deleted != nil {
+		t.Errorf("goal after undo = %v, %v; want deleted", deleted, err)
+	}
+
+	mustRedo(t)
+	if restored := mustGet(t, g.ID); restored.Title != "Write report" {
+		t.Errorf("title after redo = %q; want %q", restored.Title, "Write report")
+	}
+}
+
+func TestUndoRedo_Update(t *testing.T) {
+	ClearHistory()
+	g := addGoal(t, "Draft", nil)
+
+	g.Title = "Final"
+	if err := UpdateGoal(g); err != nil {
+		t.Fatalf("UpdateGoal returned %v", err)
+	}
+
+	mustUndo(t)
+	if title := mustGet(t, g.ID).Title; title != "Draft" {
+		t.Errorf("title after undo = %q; want %q", title, "Draft")
+	}
+
+	mustRedo(t)
+	if title := mustGet(t, g.ID).Title; title != "Final" {
+		t.Errorf("title after redo = %q; want %q", title, "Final")
+	}
+}
+
+func TestUndoRedo_Delete(t *testing.T) {
+	ClearHistory()
+	g := addGoal(t, "Old idea", nil)
+
+	// Deleting a goal archives it
+	g.IsArchived = true
+	if err := UpdateGoal(g); err != nil {
+		t.Fatalf("UpdateGoal returned %v", err)
+	}
+
+	mustUndo(t)
+	if restored := mustGet(t, g.ID); restored.IsArchived || restored.ArchivedAt != nil {
+		t.Errorf("goal after undo is archived at %v; want not archived", restored.ArchivedAt)
+	}
+
+	mustRedo(t)
+	if !mustGet(t, g.ID).IsArchived {
+		t.Errorf("goal after redo is not archived")
+	}
+}
+
+func TestUndoRedo_AddKeepsSubgoals(t *testing.T) {
+	ClearHistory()
+	parent := addGoal(t, "Parent", nil)
+	child := addGoal(t, "Child", nil)
+
+	// Linked outside the history, like goals linked by another process
+	if _, err := db.ExecQuery("UPDATE goals SET parent_id = ? WHERE id = ?", parent.ID, child.ID); err != nil {
+		t.Fatal(err)
+	}
+	ClearHistory()
+	parent = *mustGet(t, parent.ID)
+	recordChange(historyEntry{goals: []goalChange{{after: &parent}}})
+
+	// Undoing the creation of the parent deletes it and unlinks the child
+	mustUndo(t)
+	if parentID := mustGet(t, child.ID).ParentId; parentID != nil {
+		t.Errorf("parent of child after undo = %s; want none", *parentID)
+	}
+	if e := lastEvent(t, child.ID); e.Kind != goal.Reparented || e.OldValue != "Parent" {
+		t.Errorf("last event of child after undo = %s %q; want %s %q", e.Kind, e.OldValue, goal.Reparented, "Parent")
+	}
+
+	mustRedo(t)
+	if parentID := mustGet(t, child.ID).ParentId; parentID == nil || *parentID != parent.ID {
+		t.Errorf("parent of child after redo = %v; want %s", parentID, parent.ID)
+	}
+}
+
+func TestUndo_RecreatesSeriesGoal(t *testing.T) {
+	ClearHistory()
+	g := addGoal(t, "Stretch", nil)
+	if err := SetGoalRecurrence(g, goal.Daily); err != nil {
+		t.Fatalf("SetGoalRecurrence returned %v", err)
+	}
+
+	renamed := *mustGet(t, g.ID)
+	renamed.Title = "Stretch longer"
+	if err := UpdateSeriesGoal(renamed); err != nil {
+		t.Fatalf("UpdateSeriesGoal returned %v", err)
+	}
+
+	// Deleted outside the history, like future goals of a series whose rule changed
+	if err := db.WithTransaction(func(tx *sql.Tx) error { return deleteGoalRow(tx, g.ID) }); err != nil {
+		t.Fatal(err)
+	}
+
+	mustUndo(t)
+	restored := mustGet(t, g.ID)
+	if restored.SeriesID == nil || *restored.SeriesID != *renamed.SeriesID || restored.SeriesPeriod == nil {
+		t.Errorf("restored goal is in series %v for period %v; want series %s", restored.SeriesID, restored.SeriesPeriod, *renamed.SeriesID)
+	}
+	if restored.Title != "Stretch" || restored.Recurrence != goal.Daily {
+		t.Errorf("restored goal %q repeats %q; want %q repeating daily", restored.Title, restored.Recurrence, "Stretch")
+	}
+
+	series, err := GetAllSeries()
+	if err != nil {
+		t.Fatalf("GetAllSeries returned %v", err)
+	}
+	for _, s := range series {
+		if s.ID == *renamed.SeriesID && s.Title != "Stretch" {
+			t.Errorf("series title after undo = %q; want %q", s.Title, "Stretch")
+		}
+	}
+}
+
+func TestUndoRedo_CompletedParents(t *testing.T) {
+	if err := config.Set("progress.auto_complete_parents", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer config.Set("progress.auto_complete_parents", "false")
+
+	ClearHistory()
+	parent := addGoal(t, "Launch", nil)
+	child := addGoal(t, "Ship", &parent.ID)
+
+	child.IsDone = true
+	if err := UpdateGoal(child); err != nil {
+		t.Fatalf("UpdateGoal returned %v", err)
+	}
+	if !mustGet(t, parent.ID).IsDone {
+		t.Fatalf("parent is not done after its last subgoal was")
+	}
+
+	mustUndo(t)
+	if mustGet(t, child.ID).IsDone || mustGet(t, parent.ID).IsDone {
+		t.Errorf("goals are still done after undo")
+	}
+
+	mustRedo(t)
+	if !mustGet(t, child.ID).IsDone || !mustGet(t, parent.ID).IsDone {
+		t.Errorf("goals are not done after redo")
+	}
+}
+
+func TestRedo_ClearedByNewChange(t *testing.T) {
+	ClearHistory()
+	addGoal(t, "First", nil)
+	mustUndo(t)
+
+	addGoal(t, "Second", nil)
+	if ok, err := Redo(); ok || err != nil {
+		t.Errorf("Redo returned %v, %v; want nothing to redo", ok, err)
+	}
+}
+
+func TestClearHistory_AfterRestore(t *testing.T) {
+	ClearHistory()
+	kept := addGoal(t, "Kept", nil)
+
+	backup, err := db.CreateBackup()
+	if err != nil {
+		t.Fatalf("CreateBackup returned %v", err)
+	}
+
+	added := addGoal(t, "Added after the backup", nil)
+
+	// The backups screen clears the history once the restore succeeded
+	if _, err := db.RestoreBackup(backup); err != nil {
+		t.Fatalf("RestoreBackup returned %v", err)
+	}
+	ClearHistory()
+
+	if ok, err := Undo(); ok || err != nil {
+		t.Errorf("Undo returned %v, %v; want nothing to undo", ok, err)
+	}
+	if g, err := getGoal(added.ID); err != nil || g != nil {
+		t.Errorf("goal added after the backup = %v, %v; want none", g, err)
+	}
+	mustGet(t, kept.ID)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/goal"
+	"strings"
+	"time"
+)
+
+// periodKey identifies the period of a timeframe containing date in the period_notes table.
+// Weeks are identified by their Monday rather than their first day, which depends on
+// calendar.week_start, so that their notes are still found once it changes
+func periodKey(timeframe goal.Timeframe, date time.Time) string {
+	start := dates.StartOfPeriod(date, timeframe)
+	if timeframe == goal.Week {
+		start = start.AddDate(0, 0, (int(time.Monday)-int(start.Weekday())+7)%7)
+	}
+	return dates.TimeframeDateString(start)
+}
+
+// GetPeriodNote retrieves the note written for the period of a timeframe containing date,
+// empty if there is none
+func GetPeriodNote(timeframe goal.Timeframe, date time.Time) (string, error) {
+	var text string
+	err := db.QueryRowDB(
+		`SELECT text FROM period_notes WHERE timeframe = ? AND period = ?`,
+		string(timeframe), periodKey(timeframe, date),
+	).Scan(&text)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return text, err
+}
+
+// SavePeriodNote stores the note of the period of a timeframe containing date.
+// An empty note deletes it
+func SavePeriodNote(timeframe goal.Timeframe, date time.Time, text string) error {
+	period := periodKey(timeframe, date)
+
+	if strings.TrimSpace(text) == "" {
+		_, err := db.ExecQuery(`DELETE FROM period_notes WHERE timeframe = ? AND period = ?`, string(timeframe), period)
+		return err
+	}
+
+	_, err := db.ExecQuery(`
+		INSERT INTO period_notes (timeframe, period, text) VALUES (?, ?, ?)
+		ON CONFLICT (timeframe, period) DO UPDATE SET text = excluded.text, updated_at = CURRENT_TIMESTAMP
+	`, string(timeframe), period, text)
+	return err
+}
+
+// PeriodNote is the journal of a period, such as a week's plan and reflection
+type PeriodNote struct {
+	Timeframe goal.Timeframe `json:"timeframe"`
+	// Start is the first day of the period
+	Start     time.Time `json:"start"`
+	Text      string    `json:"text"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SearchPeriodNotes searches for period notes containing the term, most recent period first
+func SearchPeriodNotes(term string, limit int) ([]PeriodNote, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	trimmed := strings.TrimSpace(term)
+	if trimmed == "" {
+		return []PeriodNote{}, nil
+	}
+
+	rows, err := db.QueryDB(`
+		SELECT timeframe, period, text, updated_at
+		FROM period_notes
+		WHERE LOWER(text) LIKE LOWER(?)
+		ORDER BY period DESC, updated_at DESC
+		LIMIT ?
+	`, "%"+trimmed+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPeriodNotes(rows)
+}
+
+// GetAllPeriodNotes retrieves the notes of every period, oldest period first
+func GetAllPeriodNotes() ([]PeriodNote, error) {
+	rows, err := db.QueryDB(`
+		SELECT timeframe, period, text, updated_at
+		FROM period_notes
+		ORDER BY period ASC, timeframe ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPeriodNotes(rows)
+}
+
+// scanPeriodNotes reads all rows of period notes and closes them
+func scanPeriodNotes(rows *sql.Rows) ([]PeriodNote, error) {
+	defer rows.Close()
+
+	var notes []PeriodNote
+	for rows.Next() {
+		var note PeriodNote
+		var period string
+		if err := rows.Scan(&note.Timeframe, &period, &note.Text, &note.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		start, err := time.ParseInLocation("2006-01-02", period, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid period %q: %w", period, err)
+		}
+		note.Start = dates.StartOfPeriod(start, note.Timeframe)
+		notes = append(notes, note)
+	}
+
+	return notes, rows.Err()
+}
+
+// importPeriodNotes writes imported period notes, replacing the notes of the same periods
+func importPeriodNotes(tx *sql.Tx, notes []PeriodNote) error {
+	for _, note := range notes {
+		_, err := tx.Exec(`
+			INSERT INTO period_notes (timeframe, period, text, updated_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT (timeframe, period) DO UPDATE SET text = excluded.text, updated_at = excluded.updated_at
+		`, string(note.Timeframe), periodKey(note.Timeframe, note.Start), note.Text, note.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to import %s note of %s: %w", note.Timeframe, dates.TimeframeDateString(note.Start), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/goal"
+	"testing"
+	"time"
+)
+
+func TestPeriodNote_KeptWhenWeekStartChanges(t *testing.T) {
+	defer dates.SetWeekStart(time.Monday)
+
+	// Monday to Sunday, then Sunday to Saturday: both weeks hold Tuesday 19 November
+	date := time.Date(2024, 11, 19, 9, 0, 0, 0, time.Local)
+	if err := SavePeriodNote(goal.Week, date, "Prepare the offsite"); err != nil {
+		t.Fatalf("SavePeriodNote returned %v", err)
+	}
+
+	dates.SetWeekStart(time.Sunday)
+
+	if text, err := GetPeriodNote(goal.Week, date); err != nil || text != "Prepare the offsite" {
+		t.Errorf("week note %q, %v; want %q", text, err, "Prepare the offsite")
+	}
+
+	notes, err := GetAllPeriodNotes()
+	if err != nil {
+		t.Fatalf("GetAllPeriodNotes returned %v", err)
+	}
+	for _, note := range notes {
+		if note.Text == "Prepare the offsite" && note.Start.Weekday() != time.Sunday {
+			t.Errorf("week note starts on %s; want the first day of the week, Sunday", note.Start.Weekday())
+		}
+	}
+}
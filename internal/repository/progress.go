@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"hinoki-cli/internal/config"
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/goal"
+	"strings"
+)
+
+// GetProgress rolls up the progress of every given goal over its whole subtree.
+// Goals without children are left out of the result
+func GetProgress(ids []string) (map[string]goal.Progress, error) {
+	result := make(map[string]goal.Progress)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	// The path of visited ids stops the recursion on parent cycles
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	query := `
+		WITH RECURSIVE subtree(root_id, id, parent_id, is_done, path) AS (
+			SELECT g.parent_id, g.id, g.parent_id, g.is_done, ',' || g.parent_id || ',' || g.id || ','
+			FROM goals g
+			WHERE g.parent_id IN (` + placeholders + `) AND g.is_archived IS NOT true
+			UNION ALL
+			SELECT s.root_id, g.id, g.parent_id, g.is_done, s.path || g.id || ','
+			FROM goals g
+			JOIN subtree s ON g.parent_id = s.id
+			WHERE g.is_archived IS NOT true AND INSTR(s.path, ',' || g.id || ',') = 0
+		)
+		SELECT root_id, id, parent_id, is_done FROM subtree
+	`
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := db.QueryDB(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	descendants := make(map[string][]goal.Goal)
+	for rows.Next() {
+		var rootID string
+		var g goal.Goal
+		if err := rows.Scan(&rootID, &g.ID, &g.ParentId, &g.IsDone); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		descendants[rootID] = append(descendants[rootID], g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Broken settings fall back to counting every descendant once
+	settings, _ := config.GetProgressSettings()
+
+	for rootID, goals := range descendants {
+		result[rootID] = goal.RollUp(rootID, goals, settings.Weighted)
+	}
+
+	return result, nil
+}
+
+// FillProgress sets the progress of the goals that have children
+func FillProgress(goals []goal.Goal) error {
+	ids := make([]string, len(goals))
+	for i, g := range goals {
+		ids[i] = g.ID
+	}
+
+	progress, err := GetProgress(ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range goals {
+		if p, ok := progress[goals[i].ID]; ok {
+			goals[i].Progress = &p
+		}
+	}
+
+	return nil
+}
+
+// completeParents marks the ancestors of a goal done, starting from parentID,
+// for as long as all of their children are done. Returns the IDs of the completed goals
+func completeParents(tx *sql.Tx, parentID string) ([]string, error) {
+	visited := make(map[string]bool)
+	var completed []string
+
+	for parentID != "" && !visited[parentID] {
+		visited[parentID] = true
+
+		var undone int
+		err := tx.QueryRow(`
+			SELECT COUNT(*) FROM goals
+			WHERE parent_id = ? AND is_done = 0 AND is_archived IS NOT true
+		`, parentID).Scan(&undone)
+		if err != nil {
+			return completed, err
+		}
+		if undone > 0 {
+			return completed, nil
+		}
+
+		var grandparentID sql.NullString
+		var isDone bool
+		err = tx.QueryRow("SELECT parent_id, is_done FROM goals WHERE id = ?", parentID).Scan(&grandparentID, &isDone)
+		if err == sql.ErrNoRows {
+			return completed, nil
+		}
+		if err != nil {
+			return completed, err
+		}
+
+		if !isDone {
+			if _, err := tx.Exec("UPDATE goals SET is_done = 1 WHERE id = ?", parentID); err != nil {
+				return completed, err
+			}
+			completed = append(completed, parentID)
+		}
+
+		parentID = grandparentID.String
+	}
+
+	return completed, nil
+}
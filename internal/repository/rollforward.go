@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+	"hinoki-cli/internal/config"
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/goal"
+	"time"
+)
+
+// Postponement is an unfinished goal and the date it is rolled forward to
+type Postponement struct {
+	Goal goal.Goal
+	Date time.Time
+}
+
+// PlanRollForward lists where the unfinished goals among goals move when they are rolled forward
+// to their next period, or to the current one for goals more than a period late. Goals without a
+// period, such as life goals, stay where they are
+func PlanRollForward(goals []goal.Goal, now time.Time) []Postponement {
+	var plan []Postponement
+	for _, g := range goals {
+		if g.IsDone || g.IsArchived || g.Date == nil || g.Timeframe == nil || *g.Timeframe == goal.Life {
+			continue
+		}
+		plan = append(plan, Postponement{Goal: g, Date: dates.RollForward(*g.Date, *g.Timeframe, now)})
+	}
+	return plan
+}
+
+// RollForward moves the goals of a plan in a single transaction and returns how many were moved.
+// Goals done, archived or deleted since the plan was made stay where they are. With count_postponed
+// set, the postponed count of every moved goal is increased. The whole roll-forward is undone at
+// once with Undo
+func RollForward(plan []Postponement) (int, error) {
+	settings, _ := config.GetRollForwardSettings()
+
+	increment := 0
+	if settings.CountPostponed {
+		increment = 1
+	}
+
+	var entry historyEntry
+
+	err := db.WithTransaction(func(tx *sql.Tx) error {
+		for _, p := range plan {
+			before, err := getGoalTx(tx, p.Goal.ID)
+			if err != nil {
+				return err
+			}
+			if before == nil || before.IsDone || before.IsArchived {
+				continue
+			}
+
+			_, err = tx.Exec(`
+				UPDATE goals SET date = ?, postponed_count = COALESCE(postponed_count, 0) + ? WHERE id = ?
+			`, p.Date, increment, p.Goal.ID)
+			if err != nil {
+				return err
+			}
+
+			after, err := getGoalTx(tx, p.Goal.ID)
+			if err != nil {
+				return err
+			}
+
+			entry.goals = append(entry.goals, goalChange{before: before, after: after})
+			if err := recordEvents(tx, before, *after); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if !entry.isEmpty() {
+		recordChange(entry)
+	}
+	return len(entry.goals), nil
+}
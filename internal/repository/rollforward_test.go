@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/goal"
+	"testing"
+	"time"
+)
+
+func TestRollForward_SkipsGoalsFinishedSincePlanned(t *testing.T) {
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+
+	open := addGoal(t, "Answer emails", nil)
+	finished := addGoal(t, "Book flights", nil)
+	for _, g := range []*goal.Goal{&open, &finished} {
+		g.Date = &yesterday
+		if err := UpdateGoal(*g); err != nil {
+			t.Fatalf("UpdateGoal returned %v", err)
+		}
+	}
+
+	plan := PlanRollForward([]goal.Goal{open, finished}, now)
+
+	finished.IsDone = true
+	if err := UpdateGoal(finished); err != nil {
+		t.Fatalf("UpdateGoal returned %v", err)
+	}
+
+	count, err := RollForward(plan)
+	if err != nil || count != 1 {
+		t.Fatalf("RollForward returned %d, %v; want 1 goal moved", count, err)
+	}
+
+	if moved := mustGet(t, open.ID); dates.TimeframeDateString(*moved.Date) != dates.TimeframeDateString(now) || moved.Postponed != 1 {
+		t.Errorf("open goal on %s postponed %d times; want today, once", moved.Date, moved.Postponed)
+	}
+	if kept := mustGet(t, finished.ID); dates.TimeframeDateString(*kept.Date) != dates.TimeframeDateString(yesterday) || kept.Postponed != 0 {
+		t.Errorf("finished goal on %s postponed %d times; want yesterday, never", kept.Date, kept.Postponed)
+	}
+}
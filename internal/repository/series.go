@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"hinoki-cli/internal/config"
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/goal"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Series is the rule a repeating goal is generated by, along with the title, tags and parent
+// of the goals it generates
+type Series struct {
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Tags        []string        `json:"tags"`
+	ParentID    *string         `json:"parentId"`
+	Rule        goal.Recurrence `json:"rule"`
+	StartPeriod string          `json:"startPeriod"` // First period the series generates a goal for
+	StoppedAt   *time.Time      `json:"stoppedAt"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+// generateSeriesGoals creates the missing goals of repeating series for the period containing date
+// Past periods are never filled in, so starting a series doesn't rewrite history
+func generateSeriesGoals(timeframe goal.Timeframe, date time.Time) error {
+	periodStart := dates.StartOfPeriod(date, timeframe)
+	if timeframe == goal.Life || periodStart.Before(dates.StartOfPeriod(time.Now(), timeframe)) {
+		return nil
+	}
+
+	period := dates.TimeframeDateString(periodStart)
+
+	activeSeries, err := getActiveSeries()
+	if err != nil {
+		return err
+	}
+
+	var due []Series
+	for _, s := range activeSeries {
+		if s.Rule.Timeframe() == timeframe && s.Rule.Matches(periodStart) && period >= s.StartPeriod {
+			due = append(due, s)
+		}
+	}
+
+	if len(due) == 0 {
+		return nil
+	}
+
+	return db.WithTransaction(func(tx *sql.Tx) error {
+		for _, s := range due {
+			id := uuid.New().String()
+
+			// The unique series period index skips periods that already have a goal,
+			// including goals that were archived or moved to another date since
+			result, err := tx.Exec(`
+				INSERT OR IGNORE INTO goals (id, parent_id, title, is_done, timeframe, date, series_id, series_period)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			`, id, s.ParentID, s.Title, false, timeframe, dates.GoalDate(periodStart), s.ID, period)
+			if err != nil {
+				return err
+			}
+
+			if inserted, _ := result.RowsAffected(); inserted == 1 {
+				if err := setGoalTags(tx, id, s.Tags); err != nil {
+					return err
+				}
+				if err := recordEvents(tx, nil, goal.Goal{ID: id, Title: s.Title}); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func getActiveSeries() ([]Series, error) {
+	return querySeries(`WHERE stopped_at IS NULL`)
+}
+
+// GetAllSeries retrieves every series, including stopped ones
+func GetAllSeries() ([]Series, error) {
+	return querySeries(`ORDER BY created_at ASC, id ASC`)
+}
+
+// seriesColumns are the columns read by scanSeries
+const seriesColumns = `id, title, COALESCE(tags, ''), parent_id, rule, start_period, stopped_at, created_at`
+
+func querySeries(clause string) ([]Series, error) {
+	rows, err := db.QueryDB(`SELECT ` + seriesColumns + ` FROM series ` + clause)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Series
+	for rows.Next() {
+		s, err := scanSeries(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
+
+// scanSeries reads a row selected with seriesColumns
+func scanSeries(row rowScanner) (Series, error) {
+	var s Series
+	var tags string
+	if err := row.Scan(&s.ID, &s.Title, &tags, &s.ParentID, &s.Rule, &s.StartPeriod, &s.StoppedAt, &s.CreatedAt); err != nil {
+		return s, err
+	}
+	if tags != "" {
+		s.Tags = strings.Split(tags, ",")
+	}
+	return s, nil
+}
+
+// SetGoalRecurrence makes the goal repeat by rule from its own period on. If the goal already
+// repeats, the rule of its series is changed and undone goals of later periods are generated again
+func SetGoalRecurrence(g goal.Goal, rule goal.Recurrence) error {
+	if g.Timeframe == nil || g.Date == nil {
+		return fmt.Errorf("goal has no period to repeat from")
+	}
+
+	if rule.Timeframe() != *g.Timeframe {
+		return fmt.Errorf("%s goals can't repeat %s", strings.ToLower(g.Timeframe.String()), rule)
+	}
+
+	return db.WithTransaction(func(tx *sql.Tx) error {
+		if g.Recurrence != "" && g.SeriesID != nil {
+			if _, err := tx.Exec("UPDATE series SET rule = ? WHERE id = ?", rule, *g.SeriesID); err != nil {
+				return err
+			}
+
+			return deleteFutureSeriesGoals(tx, *g.SeriesID, *g.Timeframe)
+		}
+
+		seriesID := uuid.New().String()
+		period := dates.PeriodString(*g.Date, *g.Timeframe)
+
+		_, err := tx.Exec(`
+			INSERT INTO series (id, title, tags, parent_id, rule, start_period)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, seriesID, g.Title, strings.Join(g.Tags, ","), g.ParentId, rule, period)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec("UPDATE goals SET series_id = ?, series_period = ? WHERE id = ?", seriesID, period, g.ID)
+		return err
+	})
+}
+
+// StopGoalRecurrence stops the series of the goal. Goals of the current and past periods are kept
+func StopGoalRecurrence(g goal.Goal) error {
+	if g.SeriesID == nil || g.Timeframe == nil {
+		return nil
+	}
+
+	return db.WithTransaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec("UPDATE series SET stopped_at = CURRENT_TIMESTAMP WHERE id = ?", *g.SeriesID); err != nil {
+			return err
+		}
+
+		return deleteFutureSeriesGoals(tx, *g.SeriesID, *g.Timeframe)
+	})
+}
+
+// UpdateSeriesGoal saves a repeating goal like UpdateGoal, and copies its title and tags to its
+// series and to the undone goals of the series in later periods. Undo reverts all of it at once
+func UpdateSeriesGoal(g goal.Goal) error {
+	settings, _ := config.GetProgressSettings()
+
+	var entry historyEntry
+
+	err := db.WithTransaction(func(tx *sql.Tx) error {
+		var err error
+		if entry.goals, err = updateGoalTx(tx, g, settings); err != nil || len(entry.goals) == 0 {
+			return err
+		}
+
+		if g.SeriesID == nil || g.Recurrence == "" {
+			return nil
+		}
+
+		return updateSeriesFromGoalTx(tx, g, &entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !entry.isEmpty() {
+		recordChange(entry)
+	}
+	return nil
+}
+
+// updateSeriesFromGoalTx copies the title and tags of a goal to its series and to the undone goals
+// of the series in later periods, adding the changes to entry
+func updateSeriesFromGoalTx(tx *sql.Tx, g goal.Goal, entry *historyEntry) error {
+	before, err := scanSeries(tx.QueryRow(`SELECT `+seriesColumns+` FROM series WHERE id = ?`, *g.SeriesID))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("UPDATE series SET title = ?, tags = ? WHERE id = ?", g.Title, strings.Join(g.Tags, ","), *g.SeriesID); err != nil {
+		return err
+	}
+
+	after := before
+	after.Title, after.Tags = g.Title, g.Tags
+	entry.series = append(entry.series, seriesChange{before: before, after: after})
+
+	ids, err := queryIDs(tx, `
+		SELECT id FROM goals
+		WHERE series_id = ? AND is_done = 0 AND is_archived IS NOT true
+		AND series_period > (SELECT series_period FROM goals WHERE id = ?)
+	`, *g.SeriesID, g.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		goalBefore, err := getGoalTx(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec("UPDATE goals SET title = ? WHERE id = ?", g.Title, id); err != nil {
+			return err
+		}
+		if err := setGoalTags(tx, id, g.Tags); err != nil {
+			return err
+		}
+
+		goalAfter, err := getGoalTx(tx, id)
+		if err != nil {
+			return err
+		}
+		entry.goals = append(entry.goals, goalChange{before: goalBefore, after: goalAfter})
+
+		if err := recordEvents(tx, goalBefore, *goalAfter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteFutureSeriesGoals removes undone goals the series generated for periods after the current one
+func deleteFutureSeriesGoals(tx *sql.Tx, seriesID string, timeframe goal.Timeframe) error {
+	current := dates.PeriodString(time.Now(), timeframe)
+
+	ids, err := queryIDs(tx, `
+		SELECT id FROM goals
+		WHERE series_id = ? AND series_period > ? AND is_done = 0 AND is_archived IS NOT true
+	`, seriesID, current)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := deleteGoalRow(tx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func queryIDs(tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
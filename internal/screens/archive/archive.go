@@ -0,0 +1,405 @@
+package archive
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/repository"
+	"hinoki-cli/internal/screens"
+	"hinoki-cli/internal/theme"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	Normal = iota
+	ConfirmDelete
+)
+
+type State int
+
+type ArchiveScreen struct {
+	rows   []archiveRow
+	cursor int // Index of the selected row, always a goal row
+	offset int // First visible row
+	keys   keyMap
+	state  State
+
+	width, height int
+
+	// Temporary message to display (e.g., restore result)
+	message string
+}
+
+// archiveRow is either a period header or an archived goal listed under it
+type archiveRow struct {
+	header string
+	goal   *goal.Goal
+}
+
+var (
+	headerStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextPrimary()).
+			MarginBottom(2).
+			PaddingTop(2)
+
+	periodStyle = lipgloss.NewStyle().
+			Foreground(theme.TextSecondary()).
+			Bold(true)
+
+	goalStyle     = lipgloss.NewStyle().Foreground(theme.TextPrimary())
+	selectedStyle = theme.SelectedStyle()
+	metaStyle     = lipgloss.NewStyle().Foreground(theme.TextMuted())
+
+	messageStyle = lipgloss.NewStyle().
+			Foreground(theme.TextSecondary()).
+			MarginTop(1).
+			Italic(true)
+)
+
+const (
+	maxWidth = 130
+)
+
+type archivedGoalsResult struct {
+	goals []goal.Goal
+}
+
+type restoreResult struct {
+	title   string
+	subtree bool
+	err     error
+}
+
+type deleteResult struct {
+	title string
+	err   error
+}
+
+// ClearMessageMsg is sent to clear the temporary message
+type ClearMessageMsg struct{}
+
+func NewArchiveScreen() screens.Screen {
+	return &ArchiveScreen{keys: newKeyMap()}
+}
+
+func (m *ArchiveScreen) Init() tea.Cmd {
+	return m.getArchivedGoalsCmd()
+}
+
+func (m *ArchiveScreen) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKeyMsg(msg)
+	case archivedGoalsResult:
+		m.setRows(msg.goals)
+	case restoreResult:
+		switch {
+		case msg.err != nil:
+			m.message = fmt.Sprintf("❌ Restore failed: %v", msg.err)
+		case msg.subtree:
+			m.message = fmt.Sprintf("✅ Restored %q with its subgoals", msg.title)
+		default:
+			m.message = fmt.Sprintf("✅ Restored %q", msg.title)
+		}
+		return tea.Batch(m.getArchivedGoalsCmd(), m.clearMessageAfter(5*time.Second))
+	case deleteResult:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("❌ Delete failed: %v", msg.err)
+		} else {
+			m.message = fmt.Sprintf("Deleted %q permanently", msg.title)
+		}
+		return tea.Batch(m.getArchivedGoalsCmd(), m.clearMessageAfter(5*time.Second))
+	case ClearMessageMsg:
+		m.message = ""
+	case error:
+		m.message = fmt.Sprintf("❌ %v", msg)
+	}
+
+	return nil
+}
+
+func (m *ArchiveScreen) View() string {
+	header := headerStyle.Render("Archive")
+
+	var message string
+	switch {
+	case m.state == ConfirmDelete:
+		if g := m.selectedGoal(); g != nil {
+			message = messageStyle.Render(fmt.Sprintf("Delete %q permanently? This can't be undone. (y/n)", g.Title))
+		}
+	case m.message != "":
+		message = messageStyle.Render(m.message)
+	}
+
+	style := lipgloss.NewStyle().PaddingLeft(2)
+	horizontalPadding := (m.width - maxWidth) / 2
+
+	if m.width > maxWidth {
+		style = style.PaddingLeft(horizontalPadding).PaddingRight(horizontalPadding)
+	}
+
+	contentWidth := min(m.width, maxWidth)
+	bodyHeight := m.height - lipgloss.Height(header) - lipgloss.Height(message)
+
+	var body string
+	if len(m.rows) == 0 {
+		body = metaStyle.Render("No archived goals. Archive goals with Backspace.")
+	} else {
+		body = m.renderRows(contentWidth, bodyHeight)
+	}
+
+	view := lipgloss.JoinVertical(lipgloss.Left, header, body)
+
+	if message != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, message)
+	}
+
+	return style.
+		SetString(view).
+		Render()
+}
+
+func (m *ArchiveScreen) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *ArchiveScreen) Refresh() tea.Cmd {
+	return m.getArchivedGoalsCmd()
+}
+
+func (m *ArchiveScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	if m.state == ConfirmDelete {
+		return m.handleKeyMsgInConfirmState(msg)
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.goBack):
+		return func() tea.Msg {
+			return screens.GoBack{}
+		}
+	case key.Matches(msg, m.keys.cursorUp):
+		m.moveCursor(-1)
+	case key.Matches(msg, m.keys.cursorDown):
+		m.moveCursor(1)
+	case key.Matches(msg, m.keys.restoreGoal):
+		if g := m.selectedGoal(); g != nil {
+			return m.restoreGoalCmd(*g, false)
+		}
+	case key.Matches(msg, m.keys.restoreSubtree):
+		if g := m.selectedGoal(); g != nil {
+			return m.restoreGoalCmd(*g, true)
+		}
+	case key.Matches(msg, m.keys.deleteGoal):
+		if m.selectedGoal() != nil {
+			m.state = ConfirmDelete
+		}
+	}
+
+	return nil
+}
+
+func (m *ArchiveScreen) handleKeyMsgInConfirmState(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.confirm):
+		m.state = Normal
+		if g := m.selectedGoal(); g != nil {
+			return m.deleteGoalCmd(*g)
+		}
+	case key.Matches(msg, m.keys.cancel):
+		m.state = Normal
+	}
+
+	return nil
+}
+
+// setRows groups the goals by period, most recent periods first and goals without a date last
+func (m *ArchiveScreen) setRows(goals []goal.Goal) {
+	type group struct {
+		label string
+		order int // Dated periods first, then life goals, then goals without a period
+		start time.Time
+		goals []goal.Goal
+	}
+
+	var groups []*group
+	byLabel := make(map[string]*group)
+
+	for _, g := range goals {
+		label, order, start := periodOf(g)
+		if byLabel[label] == nil {
+			byLabel[label] = &group{label: label, order: order, start: start}
+			groups = append(groups, byLabel[label])
+		}
+		byLabel[label].goals = append(byLabel[label].goals, g)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].order != groups[j].order {
+			return groups[i].order < groups[j].order
+		}
+		return groups[i].start.After(groups[j].start)
+	})
+
+	// Keep the selection on the same goal, or on the same position once it's gone
+	selectedID := ""
+	if g := m.selectedGoal(); g != nil {
+		selectedID = g.ID
+	}
+	previousCursor := m.cursor
+
+	m.rows = nil
+	m.cursor = -1
+	for _, grp := range groups {
+		m.rows = append(m.rows, archiveRow{header: grp.label})
+		for i := range grp.goals {
+			if grp.goals[i].ID == selectedID {
+				m.cursor = len(m.rows)
+			}
+			m.rows = append(m.rows, archiveRow{goal: &grp.goals[i]})
+		}
+	}
+
+	if m.cursor == -1 {
+		m.cursor = min(max(previousCursor, 0), len(m.rows)-1)
+		m.moveCursor(0)
+	}
+}
+
+// periodOf returns the label of the period a goal was planned for, the order of
+// its group and when the period starts
+func periodOf(g goal.Goal) (string, int, time.Time) {
+	if g.Timeframe == nil {
+		return "No period", 2, time.Time{}
+	}
+	if *g.Timeframe == goal.Life || g.Date == nil {
+		return g.Timeframe.String(), 1, time.Time{}
+	}
+
+	title := g.Timeframe.String()
+	if period := dates.DateString(*g.Date, *g.Timeframe); period != "" {
+		title = fmt.Sprintf("%s • %s", title, period)
+	}
+	return title, 0, dates.StartOfPeriod(*g.Date, *g.Timeframe)
+}
+
+func (m *ArchiveScreen) renderRows(width, height int) string {
+	var lines []string
+	cursorLine := 0
+
+	for i, row := range m.rows {
+		if row.goal == nil {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, periodStyle.Render(row.header))
+			continue
+		}
+
+		checkmark := " "
+		if row.goal.IsDone {
+			checkmark = "x"
+		}
+
+		style := goalStyle
+		if i == m.cursor {
+			style = selectedStyle
+			cursorLine = len(lines)
+		}
+
+		line := style.Render(fmt.Sprintf("  [%s] %s", checkmark, goal.TitleWithTags(row.goal.Title, row.goal.Tags)))
+		if meta := metaLine(*row.goal); meta != "" {
+			line = fmt.Sprintf("%s  %s", line, metaStyle.Render(meta))
+		}
+		lines = append(lines, line)
+	}
+
+	// Scroll just enough to keep the selected goal and the line above it visible
+	height = max(1, height)
+	if cursorLine-1 < m.offset {
+		m.offset = max(0, cursorLine-1)
+	}
+	if cursorLine >= m.offset+height {
+		m.offset = cursorLine - height + 1
+	}
+	m.offset = min(m.offset, max(0, len(lines)-height))
+
+	lines = lines[m.offset:min(len(lines), m.offset+height)]
+
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+}
+
+func metaLine(g goal.Goal) string {
+	var parts []string
+	if g.ParentTitle != nil {
+		parts = append(parts, "Parent: "+*g.ParentTitle)
+	}
+	if g.ArchivedAt != nil {
+		parts = append(parts, "archived "+g.ArchivedAt.Local().Format("2 January 2006"))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// moveCursor moves to the nearest goal row in the direction of delta, skipping period headers.
+// With a delta of 0 the cursor moves off a header to the closest goal
+func (m *ArchiveScreen) moveCursor(delta int) {
+	if delta == 0 {
+		if m.selectedGoal() == nil {
+			m.moveCursor(1)
+		}
+		if m.selectedGoal() == nil {
+			m.moveCursor(-1)
+		}
+		return
+	}
+
+	for i := m.cursor + delta; i >= 0 && i < len(m.rows); i += delta {
+		if m.rows[i].goal != nil {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+func (m *ArchiveScreen) selectedGoal() *goal.Goal {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return nil
+	}
+	return m.rows[m.cursor].goal
+}
+
+func (m *ArchiveScreen) getArchivedGoalsCmd() tea.Cmd {
+	return func() tea.Msg {
+		goals, err := repository.GetArchivedGoals()
+		if err != nil {
+			return err
+		}
+		return archivedGoalsResult{goals: goals}
+	}
+}
+
+func (m *ArchiveScreen) restoreGoalCmd(g goal.Goal, subtree bool) tea.Cmd {
+	return func() tea.Msg {
+		return restoreResult{title: g.Title, subtree: subtree, err: repository.RestoreGoal(g.ID, subtree)}
+	}
+}
+
+func (m *ArchiveScreen) deleteGoalCmd(g goal.Goal) tea.Cmd {
+	return func() tea.Msg {
+		return deleteResult{title: g.Title, err: repository.DeleteGoal(g.ID)}
+	}
+}
+
+func (m *ArchiveScreen) clearMessageAfter(duration time.Duration) tea.Cmd {
+	return tea.Tick(duration, func(time.Time) tea.Msg {
+		return ClearMessageMsg{}
+	})
+}
@@ -0,0 +1,52 @@
+package archive
+
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	cursorUp       key.Binding
+	cursorDown     key.Binding
+	restoreGoal    key.Binding
+	restoreSubtree key.Binding
+	deleteGoal     key.Binding
+	confirm        key.Binding
+	cancel         key.Binding
+	goBack         key.Binding
+}
+
+var (
+	restoreGoalAction    = keymap.Register("archive", "restore", "Restore goal", "r")
+	restoreSubtreeAction = keymap.Register("archive", "restore_subtree", "Restore goal with subgoals", "R")
+	deleteGoalAction     = keymap.Register("archive", "delete", "Delete goal permanently", "x", "delete")
+)
+
+func newKeyMap() keyMap {
+	return keyMap{
+		cursorUp:       screens.CursorUpKey(),
+		cursorDown:     screens.CursorDownKey(),
+		restoreGoal:    restoreGoalAction.Binding(),
+		restoreSubtree: restoreSubtreeAction.Binding(),
+		deleteGoal:     deleteGoalAction.Binding(),
+		confirm:        screens.ConfirmKey(),
+		cancel:         screens.CancelKey(),
+		goBack:         screens.GoBackKey(),
+	}
+}
+
+// HelpKeys returns the bindings of the screen for the help overlay
+func (m *ArchiveScreen) HelpKeys() []screens.KeyGroup {
+	return []screens.KeyGroup{
+		{Title: "Archive", Bindings: []key.Binding{
+			m.keys.cursorUp,
+			m.keys.cursorDown,
+			m.keys.restoreGoal,
+			m.keys.restoreSubtree,
+			m.keys.deleteGoal,
+			m.keys.goBack,
+		}},
+	}
+}
@@ -0,0 +1,81 @@
+package backups
+
+import (
+	"fmt"
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/theme"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type backupItem struct {
+	backup db.BackupInfo
+}
+
+func (i backupItem) FilterValue() string {
+	return i.backup.Name
+}
+
+type backupItemDelegate struct{}
+
+var (
+	backupMetaStyle     = lipgloss.NewStyle().Foreground(theme.TextMuted())
+	backupSelectedStyle = theme.SelectedStyle()
+)
+
+func newBackupItemDelegate() list.ItemDelegate {
+	return backupItemDelegate{}
+}
+
+func (d backupItemDelegate) Height() int { return 2 }
+
+func (d backupItemDelegate) Spacing() int { return 1 }
+
+func (d backupItemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d backupItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(backupItem)
+	if !ok {
+		return
+	}
+
+	itemStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary())
+
+	line := fmt.Sprintf("%s\n%s", item.backup.CreatedAt.Format("2 January 2006 15:04:05"), backupMetaStyle.Render(d.metaLine(item.backup)))
+
+	if index == m.Index() {
+		itemStyle = backupSelectedStyle
+	}
+
+	wrapped := lipgloss.NewStyle().Width(m.Width()).Render(line)
+	fmt.Fprint(w, itemStyle.Render(wrapped))
+}
+
+func (d backupItemDelegate) metaLine(backup db.BackupInfo) string {
+	goals := "unreadable"
+	if backup.GoalCount >= 0 {
+		goals = fmt.Sprintf("%d goals", backup.GoalCount)
+	}
+
+	return fmt.Sprintf("%s • %s • %s", backup.Name, formatSize(backup.Size), goals)
+}
+
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGT"[exp])
+}
@@ -0,0 +1,260 @@
+package backups
+
+import (
+	"fmt"
+	"time"
+
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/repository"
+	"hinoki-cli/internal/screens"
+	"hinoki-cli/internal/theme"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	Normal = iota
+	ConfirmRestore
+)
+
+type State int
+
+type BackupsScreen struct {
+	list  list.Model
+	keys  keyMap
+	state State
+
+	width, height int
+
+	// Temporary message to display (e.g., verification result)
+	message string
+}
+
+var (
+	headerStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextPrimary()).
+			MarginBottom(2).
+			PaddingTop(2)
+
+	messageStyle = lipgloss.NewStyle().
+			Foreground(theme.TextSecondary()).
+			MarginTop(1).
+			Italic(true)
+)
+
+const (
+	maxWidth = 130
+)
+
+type backupsResult struct {
+	backups []db.BackupInfo
+}
+
+type verifyResult struct {
+	name string
+	err  error
+}
+
+type restoreResult struct {
+	name             string
+	safetyBackupPath string
+	err              error
+}
+
+// ClearMessageMsg is sent to clear the temporary message
+type ClearMessageMsg struct{}
+
+func NewBackupsScreen() screens.Screen {
+	backupList := list.New([]list.Item{}, newBackupItemDelegate(), 0, 0)
+	backupList.SetShowHelp(false)
+	backupList.SetShowStatusBar(false)
+	backupList.SetShowTitle(false)
+	backupList.SetFilteringEnabled(false)
+	backupList.DisableQuitKeybindings()
+
+	backupList.KeyMap.CursorUp = screens.CursorUpKey()
+	backupList.KeyMap.CursorDown = screens.CursorDownKey()
+
+	return &BackupsScreen{
+		list: backupList,
+		keys: newKeyMap(),
+	}
+}
+
+func (m *BackupsScreen) Init() tea.Cmd {
+	return m.getBackupsCmd()
+}
+
+func (m *BackupsScreen) Update(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKeyMsg(msg)
+	case backupsResult:
+		items := make([]list.Item, 0, len(msg.backups))
+		for _, backup := range msg.backups {
+			items = append(items, backupItem{backup: backup})
+		}
+		m.list.SetItems(items)
+	case verifyResult:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("❌ %s is damaged: %v", msg.name, msg.err)
+		} else {
+			m.message = fmt.Sprintf("✅ %s passed the integrity check", msg.name)
+		}
+		cmds = append(cmds, m.clearMessageAfter(5*time.Second))
+	case restoreResult:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("❌ Restore failed: %v", msg.err)
+		} else {
+			m.message = fmt.Sprintf("✅ Restored %s. Previous database saved to %s", msg.name, msg.safetyBackupPath)
+		}
+		cmds = append(cmds, m.getBackupsCmd())
+	case ClearMessageMsg:
+		m.message = ""
+	case error:
+		m.message = fmt.Sprintf("❌ %v", msg)
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func (m *BackupsScreen) View() string {
+	header := headerStyle.Render("Backups")
+
+	var body string
+	if len(m.list.Items()) == 0 {
+		body = lipgloss.NewStyle().Foreground(theme.TextMuted()).Render("No backups yet. Press B on the timeframe screen to create one.")
+	}
+
+	var message string
+	switch {
+	case m.state == ConfirmRestore:
+		if item, ok := m.list.SelectedItem().(backupItem); ok {
+			message = messageStyle.Render(fmt.Sprintf("Restore %s? The current database will be backed up first. (y/n)", item.backup.Name))
+		}
+	case m.message != "":
+		message = messageStyle.Render(m.message)
+	}
+
+	listHeight := m.height - lipgloss.Height(header) - lipgloss.Height(message)
+
+	style := lipgloss.NewStyle().PaddingLeft(2)
+	horizontalPadding := (m.width - maxWidth) / 2
+
+	if m.width > maxWidth {
+		style = style.PaddingLeft(horizontalPadding).PaddingRight(horizontalPadding)
+	}
+
+	contentWidth := min(m.width, maxWidth)
+
+	if body == "" {
+		m.list.SetSize(contentWidth, listHeight)
+		body = m.list.View()
+	}
+
+	view := lipgloss.JoinVertical(lipgloss.Left, header, body)
+
+	if message != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, message)
+	}
+
+	return style.
+		SetString(view).
+		Render()
+}
+
+func (m *BackupsScreen) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *BackupsScreen) Refresh() tea.Cmd {
+	return m.getBackupsCmd()
+}
+
+func (m *BackupsScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	if m.state == ConfirmRestore {
+		return m.handleKeyMsgInConfirmState(msg)
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.goBack):
+		return func() tea.Msg {
+			return screens.GoBack{}
+		}
+	case key.Matches(msg, m.keys.reloadBackups):
+		return m.getBackupsCmd()
+	case key.Matches(msg, m.keys.verifyBackup):
+		if item, ok := m.list.SelectedItem().(backupItem); ok {
+			m.message = fmt.Sprintf("Verifying %s...", item.backup.Name)
+			return m.verifyBackupCmd(item.backup)
+		}
+	case key.Matches(msg, m.keys.restoreBackup):
+		if _, ok := m.list.SelectedItem().(backupItem); ok {
+			m.state = ConfirmRestore
+		}
+	default:
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return cmd
+	}
+
+	return nil
+}
+
+func (m *BackupsScreen) handleKeyMsgInConfirmState(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.confirm):
+		m.state = Normal
+		item, ok := m.list.SelectedItem().(backupItem)
+		if !ok {
+			return nil
+		}
+		m.message = fmt.Sprintf("Restoring %s...", item.backup.Name)
+		return m.restoreBackupCmd(item.backup)
+	case key.Matches(msg, m.keys.cancel):
+		m.state = Normal
+	}
+
+	return nil
+}
+
+func (m *BackupsScreen) getBackupsCmd() tea.Cmd {
+	return func() tea.Msg {
+		backups, err := db.ListBackups()
+		if err != nil {
+			return err
+		}
+		return backupsResult{backups: backups}
+	}
+}
+
+func (m *BackupsScreen) verifyBackupCmd(backup db.BackupInfo) tea.Cmd {
+	return func() tea.Msg {
+		return verifyResult{name: backup.Name, err: db.VerifyBackup(backup.Path)}
+	}
+}
+
+// restoreBackupCmd replaces the database with the backup. Changes made before the restore
+// can no longer be undone
+func (m *BackupsScreen) restoreBackupCmd(backup db.BackupInfo) tea.Cmd {
+	return func() tea.Msg {
+		safetyBackupPath, err := db.RestoreBackup(backup.Path)
+		if err == nil {
+			repository.ClearHistory()
+		}
+		return restoreResult{name: backup.Name, safetyBackupPath: safetyBackupPath, err: err}
+	}
+}
+
+func (m *BackupsScreen) clearMessageAfter(duration time.Duration) tea.Cmd {
+	return tea.Tick(duration, func(time.Time) tea.Msg {
+		return ClearMessageMsg{}
+	})
+}
@@ -0,0 +1,48 @@
+package backups
+
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	verifyBackup  key.Binding
+	restoreBackup key.Binding
+	reloadBackups key.Binding
+	confirm       key.Binding
+	cancel        key.Binding
+	goBack        key.Binding
+}
+
+var (
+	verifyBackupAction  = keymap.Register("backups", "verify", "Verify backup", "v")
+	restoreBackupAction = keymap.Register("backups", "restore", "Restore backup", "R")
+	reloadBackupsAction = keymap.Register("backups", "reload", "Reload backups", "r")
+)
+
+func newKeyMap() keyMap {
+	return keyMap{
+		verifyBackup:  verifyBackupAction.Binding(),
+		restoreBackup: restoreBackupAction.Binding(),
+		reloadBackups: reloadBackupsAction.Binding(),
+		confirm:       screens.ConfirmKey(),
+		cancel:        screens.CancelKey(),
+		goBack:        screens.GoBackKey(),
+	}
+}
+
+// HelpKeys returns the bindings of the screen for the help overlay
+func (m *BackupsScreen) HelpKeys() []screens.KeyGroup {
+	return []screens.KeyGroup{
+		{Title: "Backups", Bindings: []key.Binding{
+			m.list.KeyMap.CursorUp,
+			m.list.KeyMap.CursorDown,
+			m.keys.verifyBackup,
+			m.keys.restoreBackup,
+			m.keys.reloadBackups,
+			m.keys.goBack,
+		}},
+	}
+}
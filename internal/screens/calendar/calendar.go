@@ -0,0 +1,325 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/repository"
+	"hinoki-cli/internal/screens"
+	"hinoki-cli/internal/theme"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type CalendarScreen struct {
+	keys keyMap
+
+	// date is the selected day, the grid shows its month
+	date time.Time
+
+	counts       map[string]repository.DayCount
+	weekGoals    []goal.Goal
+	monthGoals   []goal.Goal
+	quarterGoals []goal.Goal
+
+	width, height int
+
+	message string
+}
+
+var (
+	headerStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextPrimary()).
+			MarginBottom(2).
+			PaddingTop(2)
+
+	weekdayStyle    = lipgloss.NewStyle().Foreground(theme.TextMuted())
+	dayStyle        = lipgloss.NewStyle().Foreground(theme.TextPrimary())
+	otherMonthStyle = lipgloss.NewStyle().Foreground(theme.TextDisabled())
+	todayStyle      = lipgloss.NewStyle().Bold(true).Underline(true)
+	countStyle      = lipgloss.NewStyle().Foreground(theme.TextMuted())
+	doneStyle       = lipgloss.NewStyle().Foreground(theme.Done())
+	overdueStyle    = lipgloss.NewStyle().Foreground(theme.Overdue())
+
+	sectionStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextSecondary())
+
+	goalStyle    = lipgloss.NewStyle().Foreground(theme.TextPrimary())
+	noGoalsStyle = lipgloss.NewStyle().Foreground(theme.TextMuted()).Italic(true)
+	messageStyle = lipgloss.NewStyle().Foreground(theme.TextSecondary()).MarginTop(1).Italic(true)
+	weekStyle    = lipgloss.NewStyle().MarginBottom(1)
+)
+
+const (
+	maxWidth = 130
+
+	// cellWidth fits the day number and the done/total count of its goals
+	cellWidth = 9
+	gridWidth = 7 * cellWidth
+
+	// panelGap separates the grid from the period goals next to it
+	panelGap      = 4
+	minPanelWidth = 30
+)
+
+type calendarResult struct {
+	date         time.Time
+	counts       map[string]repository.DayCount
+	weekGoals    []goal.Goal
+	monthGoals   []goal.Goal
+	quarterGoals []goal.Goal
+}
+
+// NewCalendarScreen opens the month of date with date selected
+func NewCalendarScreen(date time.Time) screens.Screen {
+	return &CalendarScreen{
+		keys:   newKeyMap(),
+		date:   dates.DateWithoutTime(date),
+		counts: make(map[string]repository.DayCount),
+	}
+}
+
+func (m *CalendarScreen) Init() tea.Cmd {
+	return m.getCalendarCmd()
+}
+
+func (m *CalendarScreen) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKeyMsg(msg)
+	case calendarResult:
+		// Results of days that were passed while moving quickly are dropped
+		if !msg.date.Equal(m.date) {
+			return nil
+		}
+		m.counts = msg.counts
+		m.weekGoals = msg.weekGoals
+		m.monthGoals = msg.monthGoals
+		m.quarterGoals = msg.quarterGoals
+		m.message = ""
+	case error:
+		m.message = fmt.Sprintf("❌ %v", msg)
+	}
+
+	return nil
+}
+
+func (m *CalendarScreen) View() string {
+	header := headerStyle.Render(dates.DateString(m.date, goal.Month))
+
+	style := lipgloss.NewStyle().PaddingLeft(2)
+	horizontalPadding := (m.width - maxWidth) / 2
+
+	if m.width > maxWidth {
+		style = style.PaddingLeft(horizontalPadding).PaddingRight(horizontalPadding)
+	}
+
+	contentWidth := min(m.width, maxWidth) - 2
+	grid := m.gridView()
+
+	// The period goals go next to the grid when they fit, and below it otherwise
+	var body string
+	if panelWidth := contentWidth - gridWidth - panelGap; panelWidth >= minPanelWidth {
+		panelHeight := max(m.height-lipgloss.Height(header), 0)
+		panel := lipgloss.NewStyle().
+			MarginLeft(panelGap).
+			MaxHeight(panelHeight).
+			Render(m.periodsView(panelWidth))
+		body = lipgloss.JoinHorizontal(lipgloss.Top, grid, panel)
+	} else {
+		body = lipgloss.JoinVertical(lipgloss.Left, grid, m.periodsView(contentWidth))
+	}
+
+	view := lipgloss.JoinVertical(lipgloss.Left, header, body)
+
+	if m.message != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, messageStyle.Render(m.message))
+	}
+
+	return style.
+		SetString(view).
+		Render()
+}
+
+// gridView renders the weeks of the month with the goal count of every day
+func (m *CalendarScreen) gridView() string {
+	days := dates.MonthGrid(m.date)
+	today := dates.DateWithoutTime(time.Now())
+
+	var weekdays strings.Builder
+	for _, day := range days[:7] {
+		weekdays.WriteString(weekdayStyle.Width(cellWidth).Render(fmt.Sprintf("%3s", dates.DayOfWeek(day))))
+	}
+
+	rows := []string{weekStyle.Render(weekdays.String())}
+	for week := 0; week < len(days); week += 7 {
+		var row strings.Builder
+		for _, day := range days[week : week+7] {
+			row.WriteString(m.dayView(day, today))
+		}
+		rows = append(rows, weekStyle.Render(row.String()))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// dayView renders a day as its number and done/total goals. Days with all goals done are shown
+// in the done color, past days with goals left in the overdue color
+func (m *CalendarScreen) dayView(day, today time.Time) string {
+	number := fmt.Sprintf("%3d", day.Day())
+
+	var count string
+	style := countStyle
+	if c, ok := m.counts[dates.TimeframeDateString(day)]; ok && c.Total > 0 {
+		count = fmt.Sprintf("%d/%d", c.Done, c.Total)
+		switch {
+		case c.Done == c.Total:
+			style = doneStyle
+		case day.Before(today):
+			style = overdueStyle
+		}
+	}
+
+	if day.Equal(m.date) {
+		return theme.SelectedStyle().Bold(true).Width(cellWidth).Render(number + " " + count)
+	}
+
+	numberStyle := dayStyle
+	if day.Month() != m.date.Month() {
+		numberStyle = otherMonthStyle
+	}
+	if day.Equal(today) {
+		numberStyle = numberStyle.Inherit(todayStyle)
+	}
+
+	return lipgloss.NewStyle().Width(cellWidth).Render(numberStyle.Render(number) + " " + style.Render(count))
+}
+
+// periodsView lists the goals of the week, month and quarter of the selected day
+func (m *CalendarScreen) periodsView(width int) string {
+	sections := []struct {
+		title string
+		goals []goal.Goal
+	}{
+		{"Week " + dates.DateString(m.date, goal.Week), m.weekGoals},
+		{dates.DateString(m.date, goal.Month), m.monthGoals},
+		{dates.DateString(m.date, goal.Quarter), m.quarterGoals},
+	}
+
+	lineStyle := lipgloss.NewStyle().MaxWidth(width)
+
+	var lines []string
+	for i, section := range sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, lineStyle.Render(sectionStyle.Render(section.title)))
+
+		if len(section.goals) == 0 {
+			lines = append(lines, noGoalsStyle.Render("No goals"))
+			continue
+		}
+		for _, g := range section.goals {
+			lines = append(lines, lineStyle.Render(goalView(g)))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func goalView(g goal.Goal) string {
+	if g.IsDone {
+		return doneStyle.Render("[x] " + g.Title)
+	}
+	return goalStyle.Render("[ ] " + g.Title)
+}
+
+func (m *CalendarScreen) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *CalendarScreen) Refresh() tea.Cmd {
+	return m.getCalendarCmd()
+}
+
+func (m *CalendarScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.goBack):
+		return func() tea.Msg {
+			return screens.GoBack{}
+		}
+	case key.Matches(msg, m.keys.openDay):
+		date := m.date
+		return func() tea.Msg {
+			return screens.OpenTimeframeScreenWithGoal{
+				Timeframe: goal.Day,
+				Date:      date,
+			}
+		}
+	case key.Matches(msg, m.keys.previousDay):
+		return m.selectDate(m.date.AddDate(0, 0, -1))
+	case key.Matches(msg, m.keys.nextDay):
+		return m.selectDate(m.date.AddDate(0, 0, 1))
+	case key.Matches(msg, m.keys.previousWeek):
+		return m.selectDate(m.date.AddDate(0, 0, -7))
+	case key.Matches(msg, m.keys.nextWeek):
+		return m.selectDate(m.date.AddDate(0, 0, 7))
+	case key.Matches(msg, m.keys.previousMonth):
+		return m.selectDate(addMonths(m.date, -1))
+	case key.Matches(msg, m.keys.nextMonth):
+		return m.selectDate(addMonths(m.date, 1))
+	case key.Matches(msg, m.keys.today):
+		return m.selectDate(dates.DateWithoutTime(time.Now()))
+	}
+
+	return nil
+}
+
+// selectDate moves the selection to date, turning the page when it is in another month
+func (m *CalendarScreen) selectDate(date time.Time) tea.Cmd {
+	if date.Year() != m.date.Year() || date.Month() != m.date.Month() {
+		m.counts = make(map[string]repository.DayCount)
+	}
+	m.date = date
+	return m.getCalendarCmd()
+}
+
+// addMonths moves date by months, keeping the day of the month where the month is long enough
+func addMonths(date time.Time, months int) time.Time {
+	first := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(date.Day(), lastDay)-1)
+}
+
+func (m *CalendarScreen) getCalendarCmd() tea.Cmd {
+	date := m.date
+	return func() tea.Msg {
+		days := dates.MonthGrid(date)
+
+		counts, err := repository.GetDayGoalCounts(days[0], days[len(days)-1])
+		if err != nil {
+			return fmt.Errorf("failed to count goals: %w", err)
+		}
+
+		result := calendarResult{date: date, counts: counts}
+		for timeframe, goals := range map[goal.Timeframe]*[]goal.Goal{
+			goal.Week:    &result.weekGoals,
+			goal.Month:   &result.monthGoals,
+			goal.Quarter: &result.quarterGoals,
+		} {
+			if *goals, err = repository.GetGoalsByDate(timeframe, date); err != nil {
+				return fmt.Errorf("failed to load %s goals: %w", timeframe, err)
+			}
+		}
+
+		return result
+	}
+}
@@ -0,0 +1,62 @@
+package calendar
+
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	previousDay   key.Binding
+	nextDay       key.Binding
+	previousWeek  key.Binding
+	nextWeek      key.Binding
+	previousMonth key.Binding
+	nextMonth     key.Binding
+	today         key.Binding
+	openDay       key.Binding
+	goBack        key.Binding
+}
+
+var (
+	previousDayAction   = keymap.Register("calendar", "previous_day", "Previous day", "left", "h")
+	nextDayAction       = keymap.Register("calendar", "next_day", "Next day", "right", "l")
+	previousWeekAction  = keymap.Register("calendar", "previous_week", "Previous week", "up", "k")
+	nextWeekAction      = keymap.Register("calendar", "next_week", "Next week", "down", "j")
+	previousMonthAction = keymap.Register("calendar", "previous_month", "Previous month", "[", "pgup")
+	nextMonthAction     = keymap.Register("calendar", "next_month", "Next month", "]", "pgdown")
+	todayAction         = keymap.Register("calendar", "today", "Today", "t")
+	openDayAction       = keymap.Register("calendar", "open_day", "Open day", "enter")
+)
+
+func newKeyMap() keyMap {
+	return keyMap{
+		previousDay:   previousDayAction.Binding(),
+		nextDay:       nextDayAction.Binding(),
+		previousWeek:  previousWeekAction.Binding(),
+		nextWeek:      nextWeekAction.Binding(),
+		previousMonth: previousMonthAction.Binding(),
+		nextMonth:     nextMonthAction.Binding(),
+		today:         todayAction.Binding(),
+		openDay:       openDayAction.Binding(),
+		goBack:        screens.GoBackKey(),
+	}
+}
+
+// HelpKeys returns the bindings of the screen for the help overlay
+func (m *CalendarScreen) HelpKeys() []screens.KeyGroup {
+	return []screens.KeyGroup{
+		{Title: "Calendar", Bindings: []key.Binding{
+			m.keys.previousDay,
+			m.keys.nextDay,
+			m.keys.previousWeek,
+			m.keys.nextWeek,
+			m.keys.previousMonth,
+			m.keys.nextMonth,
+			m.keys.today,
+			m.keys.openDay,
+			m.keys.goBack,
+		}},
+	}
+}
@@ -0,0 +1,246 @@
+package dashboard
+
+import (
+	"fmt"
+	"time"
+
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/goallist"
+	"hinoki-cli/internal/screens"
+	"hinoki-cli/internal/theme"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// section lists the goals of the current period of a timeframe
+type section struct {
+	timeframe goal.Timeframe
+	date      time.Time
+	list      goallist.GoalList
+}
+
+type DashboardScreen struct {
+	sections []*section
+	focused  int
+	keys     keyMap
+
+	width, height int
+
+	message string
+}
+
+var (
+	headerStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextPrimary()).
+			MarginBottom(1).
+			PaddingTop(1)
+
+	sectionTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(theme.TextSecondary())
+
+	periodStyle  = lipgloss.NewStyle().Foreground(theme.TextMuted())
+	messageStyle = lipgloss.NewStyle().Foreground(theme.TextSecondary()).Italic(true)
+)
+
+const (
+	maxWidth = 130
+
+	// minListHeight is the height of a goal list showing a single line
+	minListHeight = 3
+)
+
+// timeframes are the timeframes on the dashboard, from the shortest period
+var timeframes = []goal.Timeframe{goal.Day, goal.Week, goal.Month, goal.Quarter, goal.Year}
+
+func NewDashboardScreen() screens.Screen {
+	now := time.Now()
+
+	sections := make([]*section, 0, len(timeframes))
+	for _, timeframe := range timeframes {
+		s := &section{timeframe: timeframe, date: now}
+		s.list = goallist.NewGoalList(&s.timeframe, &s.date)
+		sections = append(sections, s)
+	}
+
+	return &DashboardScreen{
+		sections: sections,
+		keys:     newKeyMap(),
+	}
+}
+
+func (m *DashboardScreen) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, s := range m.sections {
+		cmds = append(cmds, s.list.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *DashboardScreen) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !m.focusedList().IsInActiveState() {
+			if cmd, ok := m.handleKeyMsg(msg); ok {
+				return cmd
+			}
+		}
+		return m.focusedList().Update(msg)
+	case error:
+		m.message = fmt.Sprintf("❌ %v", msg)
+		return nil
+	}
+
+	// Every list takes the goals of its own timeframe, and reloads after any goal changed,
+	// since a change in one period can move goals or progress of another
+	var cmds []tea.Cmd
+	for _, s := range m.sections {
+		cmds = append(cmds, s.list.Update(msg))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *DashboardScreen) View() string {
+	header := headerStyle.Render("Dashboard")
+
+	var message string
+	if m.message != "" {
+		message = messageStyle.Render(m.message)
+	}
+
+	style := lipgloss.NewStyle().PaddingLeft(2)
+	horizontalPadding := (m.width - maxWidth) / 2
+
+	if m.width > maxWidth {
+		style = style.PaddingLeft(horizontalPadding).PaddingRight(horizontalPadding)
+	}
+
+	contentWidth := min(m.width, maxWidth)
+	available := m.height - lipgloss.Height(header) - lipgloss.Height(message)
+	heights := m.sectionHeights(available)
+
+	views := []string{header}
+	for i, s := range m.sections {
+		title := m.sectionTitle(i)
+		s.list.SetSize(contentWidth, max(heights[i]-lipgloss.Height(title), 0))
+		views = append(views, lipgloss.JoinVertical(lipgloss.Left, title, s.list.View()))
+	}
+	if message != "" {
+		views = append(views, message)
+	}
+
+	return style.
+		MaxHeight(m.height).
+		SetString(lipgloss.JoinVertical(lipgloss.Left, views...)).
+		Render()
+}
+
+// sectionTitle renders the timeframe and period of a section, highlighted when it has focus
+func (m *DashboardScreen) sectionTitle(i int) string {
+	s := m.sections[i]
+
+	titleStyle := sectionTitleStyle
+	if i == m.focused {
+		titleStyle = theme.SelectedStyle().Bold(true)
+	}
+
+	title := titleStyle.Render(s.timeframe.String()) + "  " + periodStyle.Render(dates.DateString(s.date, s.timeframe))
+	if i > 0 {
+		return lipgloss.NewStyle().MarginTop(1).Render(title)
+	}
+	return title
+}
+
+// sectionHeights divides the height between the sections. Every section gets its title and a
+// line of goals, then the focused section and the ones after it grow to fit their goals.
+// Terminals too small for all sections cut off the last ones
+func (m *DashboardScreen) sectionHeights(available int) []int {
+	heights := make([]int, len(m.sections))
+	needs := make([]int, len(m.sections))
+
+	for i, s := range m.sections {
+		title := lipgloss.Height(m.sectionTitle(i))
+
+		// Goals take two lines and a blank line after them, and the list keeps a line for its
+		// page dots and one for the goal input
+		goals := max(s.list.Len()*3+2, minListHeight)
+		if s.list.IsInActiveState() {
+			goals += 2 // The input of a new or edited goal
+		}
+
+		needs[i] = title + goals
+		heights[i] = min(needs[i], title+minListHeight)
+		available -= heights[i]
+	}
+
+	for n := range m.sections {
+		i := (m.focused + n) % len(m.sections)
+		grow := max(min(needs[i]-heights[i], available), 0)
+		heights[i] += grow
+		available -= grow
+	}
+
+	return heights
+}
+
+func (m *DashboardScreen) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Refresh reloads every section, moving to the current periods if the day changed meanwhile
+func (m *DashboardScreen) Refresh() tea.Cmd {
+	now := time.Now()
+
+	var cmds []tea.Cmd
+	for _, s := range m.sections {
+		s.list.SetDate(s.timeframe, now)
+		s.date = now
+		cmds = append(cmds, s.list.RefreshData())
+	}
+	return tea.Batch(cmds...)
+}
+
+// IsCapturingInput reports whether keys go to a text input, while a goal is typed
+func (m *DashboardScreen) IsCapturingInput() bool {
+	return m.focusedList().IsInActiveState()
+}
+
+func (m *DashboardScreen) focusedList() *goallist.GoalList {
+	return &m.sections[m.focused].list
+}
+
+// handleKeyMsg handles the keys of the screen, reporting whether the key was one of them
+func (m *DashboardScreen) handleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.goBack):
+		return func() tea.Msg {
+			return screens.GoBack{}
+		}, true
+	case key.Matches(msg, m.keys.nextSection):
+		m.focused = (m.focused + 1) % len(m.sections)
+	case key.Matches(msg, m.keys.previousSection):
+		m.focused = (m.focused + len(m.sections) - 1) % len(m.sections)
+	case key.Matches(msg, m.keys.openPeriod):
+		s := m.sections[m.focused]
+		var goalID string
+		if selected := s.list.GetSelectedGoal(); selected != nil {
+			goalID = selected.ID
+		}
+		return func() tea.Msg {
+			return screens.OpenTimeframeScreenWithGoal{
+				Timeframe: s.timeframe,
+				Date:      s.date,
+				GoalID:    goalID,
+			}
+		}, true
+	default:
+		return nil, false
+	}
+
+	return nil, true
+}
@@ -0,0 +1,40 @@
+package dashboard
+
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	nextSection     key.Binding
+	previousSection key.Binding
+	openPeriod      key.Binding
+	goBack          key.Binding
+}
+
+var (
+	nextSectionAction     = keymap.Register("dashboard", "next_section", "Next timeframe", "tab")
+	previousSectionAction = keymap.Register("dashboard", "previous_section", "Previous timeframe", "shift+tab")
+	openPeriodAction      = keymap.Register("dashboard", "open_period", "Open period in timeframe", "o")
+)
+
+func newKeyMap() keyMap {
+	return keyMap{
+		nextSection:     nextSectionAction.Binding(),
+		previousSection: previousSectionAction.Binding(),
+		openPeriod:      openPeriodAction.Binding(),
+		goBack:          screens.GoBackKey(),
+	}
+}
+
+// HelpKeys returns the bindings of the screen and the focused goal list for the help overlay
+func (m *DashboardScreen) HelpKeys() []screens.KeyGroup {
+	return append(m.focusedList().HelpKeys(), screens.KeyGroup{Title: "Dashboard", Bindings: []key.Binding{
+		m.keys.nextSection,
+		m.keys.previousSection,
+		m.keys.openPeriod,
+		m.keys.goBack,
+	}})
+}
@@ -1,10 +1,14 @@
 package goaldetails
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"hinoki-cli/internal/editor"
 	"hinoki-cli/internal/goal"
 	"hinoki-cli/internal/goallist"
+	"hinoki-cli/internal/repository"
 	"hinoki-cli/internal/screens"
 	"hinoki-cli/internal/theme"
 
@@ -27,17 +31,42 @@ type GoalDetailsScreen struct {
 	actionInput textinput.Model
 	state       State
 	goal        *goal.Goal
+	progress    *goal.Progress // Progress of the whole subtree, nil without subgoals
+	events      []goal.Event   // History of the goal, most recent change first
+	showHistory bool           // The history is shown in place of the subgoals
 
 	width, height int
+
+	message string // Failure of the last notes edit
 }
 
 var (
 	actionInputLightStyle = lipgloss.NewStyle().MarginBottom(1).Foreground(theme.TextSecondary())
 	actionInputDarkStyle  = lipgloss.NewStyle().MarginBottom(1).Foreground(theme.TextSecondary())
+
+	notesStyle = lipgloss.NewStyle().
+			Foreground(theme.TextSecondary()).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderLeft(true).
+			BorderForeground(theme.TextMuted()).
+			PaddingLeft(1).
+			MarginBottom(1)
+
+	notesPlaceholderStyle = lipgloss.NewStyle().
+				Foreground(theme.TextMuted()).
+				MarginBottom(1)
+
+	historyTimeStyle = lipgloss.NewStyle().Foreground(theme.TextMuted())
+	historyTextStyle = lipgloss.NewStyle().Foreground(theme.TextSecondary())
+
+	messageStyle = lipgloss.NewStyle().Foreground(theme.TextSecondary()).MarginTop(1).Italic(true)
 )
 
 const (
 	maxWidth = 130
+	// Notes never take more than this share of the screen height, the rest is for subgoals
+	maxNotesHeightRatio = 3
+	progressBarWidth    = 30
 )
 
 type GoalsResult struct {
@@ -47,6 +76,22 @@ type GoalsResult struct {
 type AddGoalSuccess struct{}
 type UpdateGoalSuccess struct{}
 
+type notesSaved struct {
+	notes string
+}
+
+type progressResult struct {
+	progress *goal.Progress
+}
+
+type goalReloaded struct {
+	goal *goal.Goal
+}
+
+type eventsResult struct {
+	events []goal.Event
+}
+
 func NewGoalDetailsScreen(goal *goal.Goal) screens.Screen {
 	keys := NewListKeyMap()
 
@@ -69,9 +114,33 @@ func (m *GoalDetailsScreen) Update(msg tea.Msg) tea.Cmd {
 	case tea.KeyMsg:
 		cmd := m.handleKeyMsg(msg)
 
-		if cmd != nil {
+		// Subgoals are hidden behind the history, so they don't get keys
+		if cmd != nil || m.showHistory {
 			return cmd
 		}
+	case editor.FinishedMsg:
+		if msg.ID != m.goal.ID {
+			break
+		}
+		if msg.Err != nil {
+			m.message = fmt.Sprintf("❌ %v", msg.Err)
+			return nil
+		}
+		return m.saveNotesCmd(msg.Text)
+	case notesSaved:
+		m.goal.Notes = msg.notes
+		m.message = ""
+	case error:
+		m.message = fmt.Sprintf("❌ %v", msg)
+	case goallist.GoalsResult:
+		// Subgoals changed, so the roll-up may have too. An undo may have changed the goal itself
+		cmds = append(cmds, m.progressCmd(), m.reloadGoalCmd(), m.eventsCmd())
+	case progressResult:
+		m.progress = msg.progress
+	case goalReloaded:
+		m.goal = msg.goal
+	case eventsResult:
+		m.events = msg.events
 	}
 
 	if m.state == Normal {
@@ -83,7 +152,12 @@ func (m *GoalDetailsScreen) Update(msg tea.Msg) tea.Cmd {
 
 func (m *GoalDetailsScreen) View() string {
 
-	header := lipgloss.NewStyle().MarginBottom(2).PaddingTop(2).PaddingRight(8).Width(m.width).Render(lipgloss.JoinVertical(lipgloss.Left, m.goal.Title))
+	headerLines := []string{m.goal.Title}
+	if m.progress != nil {
+		headerLines = append(headerLines, goallist.ProgressBar(*m.progress, progressBarWidth))
+	}
+
+	header := lipgloss.NewStyle().MarginBottom(2).PaddingTop(2).PaddingRight(8).Width(m.width).Render(lipgloss.JoinVertical(lipgloss.Left, headerLines...))
 
 	var actionInput string
 	if m.state == GotoDate {
@@ -103,6 +177,8 @@ func (m *GoalDetailsScreen) View() string {
 		actionInput = actionInputStyle.Render(actionInput)
 	}
 
+	header = lipgloss.JoinVertical(lipgloss.Left, header, m.notesView())
+
 	headerHeight := lipgloss.Height(header)
 	actionInputHeight := 0
 
@@ -110,7 +186,14 @@ func (m *GoalDetailsScreen) View() string {
 		actionInputHeight = lipgloss.Height(actionInput)
 	}
 
-	listHeight := m.height - headerHeight - actionInputHeight
+	var message string
+	messageHeight := 0
+	if m.message != "" {
+		message = messageStyle.Render(m.message)
+		messageHeight = lipgloss.Height(message)
+	}
+
+	listHeight := m.height - headerHeight - actionInputHeight - messageHeight
 
 	style := lipgloss.NewStyle().PaddingLeft(2)
 	horizontalPadding := (m.width - maxWidth) / 2
@@ -119,14 +202,24 @@ func (m *GoalDetailsScreen) View() string {
 		style = style.PaddingLeft(horizontalPadding).PaddingRight(horizontalPadding)
 	}
 
-	m.list.SetSize(min(m.width, maxWidth), listHeight)
+	var body string
+	if m.showHistory {
+		body = m.historyView(listHeight)
+	} else {
+		m.list.SetSize(min(m.width, maxWidth), listHeight)
+		body = m.list.View()
+	}
 
-	view := lipgloss.JoinVertical(lipgloss.Left, header, m.list.View())
+	view := lipgloss.JoinVertical(lipgloss.Left, header, body)
 
 	if m.state != Normal {
 		view = lipgloss.JoinVertical(lipgloss.Left, view, actionInput)
 	}
 
+	if message != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, message)
+	}
+
 	return style.
 		SetString(view).
 		Render()
@@ -137,6 +230,11 @@ func (m *GoalDetailsScreen) SetSize(width, height int) {
 	m.height = height
 }
 
+// IsCapturingInput reports whether keys go to a text input, while a date or subgoal is typed
+func (m *GoalDetailsScreen) IsCapturingInput() bool {
+	return m.state != Normal || m.list.IsInActiveState()
+}
+
 func (m *GoalDetailsScreen) Refresh() tea.Cmd {
 	return m.list.RefreshData()
 }
@@ -160,7 +258,9 @@ func (m *GoalDetailsScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 
 func (m *GoalDetailsScreen) handleKeyMsgInNormalState(msg tea.KeyMsg) tea.Cmd {
 	switch {
-	case msg.String() == "esc":
+	case key.Matches(msg, m.keys.goBack) && m.showHistory:
+		m.showHistory = false
+	case key.Matches(msg, m.keys.goBack):
 		return func() tea.Msg {
 			return screens.GoBack{}
 		}
@@ -170,6 +270,13 @@ func (m *GoalDetailsScreen) handleKeyMsgInNormalState(msg tea.KeyMsg) tea.Cmd {
 			return nil
 		}
 		return m.openChildGoalCmd(selectedGoal)
+	case key.Matches(msg, m.keys.editNotes):
+		return editor.Edit(m.goal.ID, m.goal.Notes)
+	case key.Matches(msg, m.keys.showHistory):
+		m.showHistory = !m.showHistory
+		if m.showHistory {
+			return m.eventsCmd()
+		}
 	}
 	return nil
 }
@@ -213,3 +320,135 @@ func (m *GoalDetailsScreen) openChildGoalCmd(childGoal *goal.Goal) tea.Cmd {
 		}
 	}
 }
+
+// notesView renders the goal notes as a read-only pane, cut to a share of the screen height
+func (m *GoalDetailsScreen) notesView() string {
+	if m.goal.Notes == "" {
+		return notesPlaceholderStyle.Render("No notes. Press E to write some.")
+	}
+
+	width := min(m.width, maxWidth) - 4
+	lines := strings.Split(lipgloss.NewStyle().Width(width).Render(m.goal.Notes), "\n")
+
+	maxLines := max(1, m.height/maxNotesHeightRatio)
+	if len(lines) > maxLines {
+		lines = append(lines[:maxLines-1], "…")
+	}
+
+	return notesStyle.Render(strings.Join(lines, "\n"))
+}
+
+// historyView renders the goal history as a timeline, cut to height lines
+func (m *GoalDetailsScreen) historyView(height int) string {
+	if len(m.events) == 0 {
+		return notesPlaceholderStyle.Render("No changes recorded yet.")
+	}
+
+	lines := make([]string, 0, len(m.events))
+	for _, e := range m.events {
+		lines = append(lines, historyTimeStyle.Render(e.CreatedAt.Local().Format("2006-01-02 15:04"))+"  "+historyTextStyle.Render(describeEvent(e)))
+	}
+
+	if height > 0 && len(lines) > height {
+		hidden := len(lines) - height + 1
+		lines = append(lines[:height-1], historyTimeStyle.Render(fmt.Sprintf("… %d earlier changes", hidden)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// describeEvent returns a line of the timeline describing the change
+func describeEvent(e goal.Event) string {
+	switch e.Kind {
+	case goal.Created:
+		return fmt.Sprintf("Created as %q", e.NewValue)
+	case goal.Renamed:
+		return fmt.Sprintf("Renamed from %q to %q", e.OldValue, e.NewValue)
+	case goal.StatusChanged:
+		return "Marked " + e.NewValue
+	case goal.Rescheduled:
+		return fmt.Sprintf("Moved from %s to %s", periodOrUnscheduled(e.OldValue), periodOrUnscheduled(e.NewValue))
+	case goal.Reparented:
+		switch {
+		case e.OldValue == "":
+			return fmt.Sprintf("Linked to %q", e.NewValue)
+		case e.NewValue == "":
+			return fmt.Sprintf("Unlinked from %q", e.OldValue)
+		}
+		return fmt.Sprintf("Parent changed from %q to %q", e.OldValue, e.NewValue)
+	case goal.Archived:
+		if e.NewValue == "archived" {
+			return "Archived"
+		}
+		return "Restored from the archive"
+	}
+
+	return string(e.Kind)
+}
+
+func periodOrUnscheduled(period string) string {
+	if period == "" {
+		return "unscheduled"
+	}
+	return period
+}
+
+func (m *GoalDetailsScreen) eventsCmd() tea.Cmd {
+	goalID := m.goal.ID
+
+	return func() tea.Msg {
+		events, err := repository.GetGoalEvents(goalID)
+		if err != nil {
+			return err
+		}
+		return eventsResult{events: events}
+	}
+}
+
+func (m *GoalDetailsScreen) progressCmd() tea.Cmd {
+	goalID := m.goal.ID
+
+	return func() tea.Msg {
+		progress, err := repository.GetProgress([]string{goalID})
+		if err != nil {
+			return err
+		}
+
+		if p, ok := progress[goalID]; ok {
+			return progressResult{progress: &p}
+		}
+		return progressResult{}
+	}
+}
+
+// reloadGoalCmd reads the goal again. A goal that was archived or deleted since is kept as shown
+func (m *GoalDetailsScreen) reloadGoalCmd() tea.Cmd {
+	goalID := m.goal.ID
+
+	return func() tea.Msg {
+		g, err := repository.GetGoalByID(goalID)
+		if err != nil {
+			return nil
+		}
+		return goalReloaded{goal: g}
+	}
+}
+
+func (m *GoalDetailsScreen) saveNotesCmd(notes string) tea.Cmd {
+	goalID := m.goal.ID
+
+	return func() tea.Msg {
+		// Reload the goal so the notes don't overwrite changes made since the screen was opened
+		g, err := repository.GetGoalByID(goalID)
+		if err != nil {
+			return fmt.Errorf("failed to save notes: %w", err)
+		}
+
+		g.Notes = notes
+		if err := repository.UpdateGoal(*g); err != nil {
+			return fmt.Errorf("failed to save notes: %w", err)
+		}
+
+		return notesSaved{notes: notes}
+	}
+}
@@ -1,21 +1,40 @@
 package goaldetails
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 type listKeyMap struct {
-	goBack   key.Binding
-	openGoal key.Binding
+	goBack      key.Binding
+	openGoal    key.Binding
+	editNotes   key.Binding
+	showHistory key.Binding
 }
 
+var (
+	openGoalAction    = keymap.Register("details", "open_goal", "Open goal", "o")
+	editNotesAction   = keymap.Register("details", "edit_notes", "Edit notes in $EDITOR", "E")
+	showHistoryAction = keymap.Register("details", "show_history", "Toggle goal history", "t")
+)
+
 func NewListKeyMap() listKeyMap {
 	return listKeyMap{
-		goBack: key.NewBinding(
-			key.WithKeys("esc"),
-			key.WithHelp("Escape", "Go back"),
-		),
-		openGoal: key.NewBinding(
-			key.WithKeys("o", "щ"),
-			key.WithHelp("o", "Open goal"),
-		),
+		goBack:      screens.GoBackKey(),
+		openGoal:    openGoalAction.Binding(),
+		editNotes:   editNotesAction.Binding(),
+		showHistory: showHistoryAction.Binding(),
 	}
 }
+
+// HelpKeys returns the bindings of the screen and its subgoal list for the help overlay
+func (m *GoalDetailsScreen) HelpKeys() []screens.KeyGroup {
+	return append(m.list.HelpKeys(), screens.KeyGroup{Title: "Goal", Bindings: []key.Binding{
+		m.keys.openGoal,
+		m.keys.editNotes,
+		m.keys.showHistory,
+		m.keys.goBack,
+	}})
+}
@@ -0,0 +1,148 @@
+package screens
+
+import (
+	"strings"
+
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/theme"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// KeyGroup is a category of key bindings listed in the help overlay
+type KeyGroup struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// HelpProvider is implemented by screens that list their key bindings in the help overlay
+type HelpProvider interface {
+	HelpKeys() []KeyGroup
+}
+
+// InputCapturer is implemented by screens that may have a text input focused.
+// While it is, keys such as ? are typed into the input instead of handled by the app
+type InputCapturer interface {
+	IsCapturingInput() bool
+}
+
+var (
+	helpTitleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextPrimary()).
+			MarginBottom(1)
+
+	helpGroupStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextSecondary())
+
+	helpKeyStyle = lipgloss.NewStyle().Foreground(theme.TextPrimary())
+
+	helpDescStyle = lipgloss.NewStyle().Foreground(theme.TextMuted())
+
+	helpBoxStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(theme.TextMuted()).
+			Padding(1, 2)
+
+	hintBarStyle = lipgloss.NewStyle().PaddingLeft(2)
+)
+
+// HelpOverlay renders the enabled bindings of the groups in columns, centered on the screen
+func HelpOverlay(groups []KeyGroup, width, height int) string {
+	var columns []string
+
+	for _, group := range groups {
+		bindings := enabledBindings(group.Bindings)
+		if len(bindings) == 0 {
+			continue
+		}
+
+		keyWidth := 0
+		for _, b := range bindings {
+			keyWidth = max(keyWidth, lipgloss.Width(b.Help().Key))
+		}
+
+		lines := []string{helpGroupStyle.Render(group.Title)}
+		for _, b := range bindings {
+			keyText := helpKeyStyle.Width(keyWidth).Render(b.Help().Key)
+			lines = append(lines, keyText+"  "+helpDescStyle.Render(b.Help().Desc))
+		}
+
+		columns = append(columns, lipgloss.NewStyle().MarginRight(4).MarginBottom(1).Render(strings.Join(lines, "\n")))
+	}
+
+	// Groups are laid out in rows of as many columns as fit the screen
+	var rows []string
+	var row []string
+	rowWidth := 0
+	maxRowWidth := max(width-8, 0)
+
+	for _, column := range columns {
+		if len(row) > 0 && rowWidth+lipgloss.Width(column) > maxRowWidth {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		row = append(row, column)
+		rowWidth += lipgloss.Width(column)
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		helpTitleStyle.Render("Keys"),
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		helpDescStyle.Render("Press any key to close"),
+	)
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, helpBoxStyle.Render(content))
+}
+
+// HintBar renders the enabled bindings of the groups on a single line, cut to width
+func HintBar(groups []KeyGroup, width int) string {
+	var bindings []key.Binding
+	for _, group := range groups {
+		bindings = append(bindings, enabledBindings(group.Bindings)...)
+	}
+
+	h := help.New()
+	h.Width = max(width-2, 0)
+
+	return hintBarStyle.Render(h.ShortHelpView(bindings))
+}
+
+func enabledBindings(bindings []key.Binding) []key.Binding {
+	var result []key.Binding
+	for _, b := range bindings {
+		if b.Enabled() && b.Help().Key != "" {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+var (
+	cursorUpAction   = keymap.Register("list", "up", "Up", "up", "k")
+	cursorDownAction = keymap.Register("list", "down", "Down", "down", "j")
+	goBackAction     = keymap.Register("screen", "go_back", "Go back", "esc")
+	confirmAction    = keymap.Register("confirm", "yes", "Confirm", "y")
+	cancelAction     = keymap.Register("confirm", "no", "Cancel", "n", "esc")
+)
+
+// CursorUpKey moves the cursor up in every list
+func CursorUpKey() key.Binding { return cursorUpAction.Binding() }
+
+// CursorDownKey moves the cursor down in every list
+func CursorDownKey() key.Binding { return cursorDownAction.Binding() }
+
+// GoBackKey goes back on every screen but the timeframe screen
+func GoBackKey() key.Binding { return goBackAction.Binding() }
+
+// ConfirmKey confirms a destructive action such as deleting a goal
+func ConfirmKey() key.Binding { return confirmAction.Binding() }
+
+// CancelKey cancels a destructive action such as deleting a goal
+func CancelKey() key.Binding { return cancelAction.Binding() }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"hinoki-cli/internal/dates"
 	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/goallist"
 	"hinoki-cli/internal/repository"
 	"hinoki-cli/internal/screens"
 	"hinoki-cli/internal/theme"
@@ -17,6 +18,7 @@ import (
 type HierarchyScreen struct {
 	goal      *goal.Goal
 	ancestors []goal.Goal
+	treeNodes []TreeNode // Full tree under the root ancestor, loaded when showAll is on
 	keys      keyMap
 	showAll   bool // Toggle for showing full tree vs just ancestors
 
@@ -64,7 +66,8 @@ var (
 )
 
 const (
-	maxWidth = 130
+	maxWidth         = 130
+	progressBarWidth = 10
 )
 
 type AncestorChainResult struct {
@@ -72,6 +75,7 @@ type AncestorChainResult struct {
 }
 
 type FullTreeResult struct {
+	ancestors []goal.Goal
 	treeNodes []TreeNode
 }
 
@@ -104,8 +108,8 @@ func (m *HierarchyScreen) Update(msg tea.Msg) tea.Cmd {
 		m.ancestors = msg.ancestors
 		m.updateFlattenedItems()
 	case FullTreeResult:
-		// Convert tree nodes back to ancestors for rendering
-		m.ancestors = m.treeNodesToGoals(msg.treeNodes)
+		m.ancestors = msg.ancestors
+		m.treeNodes = msg.treeNodes
 		m.updateFlattenedItems()
 	case error:
 		// swallow errors in UI loop
@@ -149,12 +153,15 @@ func (m *HierarchyScreen) SetSize(width, height int) {
 }
 
 func (m *HierarchyScreen) Refresh() tea.Cmd {
+	if m.showAll {
+		return m.getFullTreeCmd()
+	}
 	return m.getAncestorChainCmd()
 }
 
 func (m *HierarchyScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 	switch {
-	case msg.Type == tea.KeyEsc:
+	case key.Matches(msg, m.keys.goBack):
 		return func() tea.Msg {
 			return screens.GoBack{}
 		}
@@ -185,6 +192,9 @@ func (m *HierarchyScreen) getAncestorChainCmd() tea.Cmd {
 		if err != nil {
 			return err
 		}
+		if err := repository.FillProgress(ancestors); err != nil {
+			return err
+		}
 		return AncestorChainResult{ancestors: ancestors}
 	}
 }
@@ -242,7 +252,9 @@ func (m *HierarchyScreen) renderFlattenedTree(width, height int) string {
 		var itemStyle lipgloss.Style = item.style
 		if isSelected {
 			// Highlight selected item - invert colors for visibility
-			if lipgloss.HasDarkBackground() {
+			if theme.IsMonochrome() {
+				itemStyle = item.style.Copy().Reverse(true)
+			} else if lipgloss.HasDarkBackground() {
 				itemStyle = item.style.Copy().Background(theme.TextSelected()).Foreground(theme.TextPrimary())
 			} else {
 				itemStyle = item.style.Copy().Foreground(theme.TextSelected()).Bold(true)
@@ -267,6 +279,9 @@ func (m *HierarchyScreen) renderFlattenedTree(width, height int) string {
 			if meta != "" {
 				goalLine = fmt.Sprintf("%s %s", goalLine, metaStyle.Render(meta))
 			}
+			if item.goal.Progress != nil {
+				goalLine = fmt.Sprintf("%s  %s", goalLine, goallist.ProgressBar(*item.goal.Progress, progressBarWidth))
+			}
 		}
 
 		// Combine everything
@@ -284,54 +299,60 @@ func (m *HierarchyScreen) renderFlattenedTree(width, height int) string {
 		Render(treeContent)
 }
 
-func (m *HierarchyScreen) buildFullTree() []TreeNode {
-	// Build tree structure from ancestors
-	// Ancestors form a chain, not siblings
-	if len(m.ancestors) == 0 {
-		return []TreeNode{}
-	}
+func (m *HierarchyScreen) getFullTreeCmd() tea.Cmd {
+	return func() tea.Msg {
+		ancestors, err := repository.GetAncestorChain(m.goal.ID)
+		if err != nil {
+			return err
+		}
+		if len(ancestors) == 0 {
+			return FullTreeResult{}
+		}
 
-	// Build the chain recursively, starting from root
-	return m.buildTreeNodeChain(0)
-}
+		// Load the whole tree under the root in one query
+		root := ancestors[0]
+		descendants, err := repository.GetSubtree(root.ID, 0)
+		if err != nil {
+			return err
+		}
 
-func (m *HierarchyScreen) buildTreeNodeChain(index int) []TreeNode {
-	if index >= len(m.ancestors) {
-		return []TreeNode{}
-	}
+		goals := append([]goal.Goal{root}, descendants...)
+		if err := repository.FillProgress(goals); err != nil {
+			return err
+		}
+
+		children := make(map[string][]goal.Goal)
+		for _, g := range goals[1:] {
+			children[*g.ParentId] = append(children[*g.ParentId], g)
+		}
 
-	g := m.ancestors[index]
-	isCurrent := index == len(m.ancestors)-1
+		onChain := make(map[string]bool, len(ancestors))
+		for _, g := range ancestors {
+			onChain[g.ID] = true
+		}
 
-	// Get all children for this goal
-	allChildren, _ := repository.GetGoalsByParent(g.ID)
+		rootNode := m.buildTreeNode(goals[0], 0, children, onChain)
+		rootNode.isLast = true // Only one node at root level
+
+		return FullTreeResult{ancestors: ancestors, treeNodes: []TreeNode{rootNode}}
+	}
+}
 
-	// Separate children into: ancestors in the chain vs other siblings
+// buildTreeNode builds the node of g with all of its children. The child leading
+// to the current goal is listed first, followed by its siblings
+func (m *HierarchyScreen) buildTreeNode(g goal.Goal, depth int, children map[string][]goal.Goal, onChain map[string]bool) TreeNode {
 	var chainChildren []TreeNode
 	var siblingChildren []TreeNode
 
-	for _, child := range allChildren {
-		// Check if this child is the next ancestor in the chain
-		isNextAncestor := index+1 < len(m.ancestors) && child.ID == m.ancestors[index+1].ID
-
-		if isNextAncestor {
-			// This is the next ancestor in the chain - build it recursively
-			nextChain := m.buildTreeNodeChain(index + 1)
-			chainChildren = append(chainChildren, nextChain...)
+	for _, child := range children[g.ID] {
+		node := m.buildTreeNode(child, depth+1, children, onChain)
+		if onChain[child.ID] {
+			chainChildren = append(chainChildren, node)
 		} else {
-			// This is a sibling - get its children recursively
-			siblingChildNodes := m.getChildrenRecursive(child.ID, index+1)
-			siblingChildren = append(siblingChildren, TreeNode{
-				goal:      child,
-				children:  siblingChildNodes,
-				depth:     index + 1,
-				isCurrent: child.ID == m.goal.ID,
-				isLast:    false, // Will be set when we combine
-			})
+			siblingChildren = append(siblingChildren, node)
 		}
 	}
 
-	// Combine chain children and sibling children
 	allChildNodes := append(chainChildren, siblingChildren...)
 
 	// Mark the last child as last
@@ -339,83 +360,11 @@ func (m *HierarchyScreen) buildTreeNodeChain(index int) []TreeNode {
 		allChildNodes[len(allChildNodes)-1].isLast = true
 	}
 
-	// Return this node with its children
-	return []TreeNode{{
+	return TreeNode{
 		goal:      g,
 		children:  allChildNodes,
-		depth:     index,
-		isCurrent: isCurrent,
-		isLast:    true, // Only one node at root level
-	}}
-}
-
-func (m *HierarchyScreen) getChildrenRecursive(goalID string, depth int) []TreeNode {
-	children, _ := repository.GetGoalsByParent(goalID)
-	if len(children) == 0 {
-		return []TreeNode{}
-	}
-
-	var childNodes []TreeNode
-	for i, child := range children {
-		childChildren := m.getChildrenRecursive(child.ID, depth+1)
-		childNodes = append(childNodes, TreeNode{
-			goal:      child,
-			children:  childChildren,
-			depth:     depth,
-			isCurrent: child.ID == m.goal.ID,
-			isLast:    i == len(children)-1,
-		})
-	}
-
-	return childNodes
-}
-
-func (m *HierarchyScreen) treeNodesToGoals(nodes []TreeNode) []goal.Goal {
-	// Flatten tree nodes back to goals for simple ancestor view
-	var goals []goal.Goal
-	for _, node := range nodes {
-		goals = append(goals, node.goal)
-	}
-	return goals
-}
-
-func (m *HierarchyScreen) getFullTreeCmd() tea.Cmd {
-	return func() tea.Msg {
-		ancestors, err := repository.GetAncestorChain(m.goal.ID)
-		if err != nil {
-			return err
-		}
-
-		// Build full tree structure
-		var treeNodes []TreeNode
-		for i, g := range ancestors {
-			isCurrent := i == len(ancestors)-1
-
-			// Get children for this goal
-			children, _ := repository.GetGoalsByParent(g.ID)
-
-			// Convert children to tree nodes
-			childNodes := make([]TreeNode, len(children))
-			for j, child := range children {
-				childNodes[j] = TreeNode{
-					goal:      child,
-					children:  []TreeNode{},
-					depth:     i + 1,
-					isCurrent: child.ID == m.goal.ID,
-					isLast:    j == len(children)-1,
-				}
-			}
-
-			treeNodes = append(treeNodes, TreeNode{
-				goal:      g,
-				children:  childNodes,
-				depth:     i,
-				isCurrent: isCurrent,
-				isLast:    i == len(ancestors)-1,
-			})
-		}
-
-		return FullTreeResult{treeNodes: treeNodes}
+		depth:     depth,
+		isCurrent: g.ID == m.goal.ID,
 	}
 }
 
@@ -445,8 +394,7 @@ func (m *HierarchyScreen) updateFlattenedItems() {
 	}
 
 	if m.showAll {
-		treeNodes := m.buildFullTree()
-		m.flattenTreeNodes(treeNodes, "", false, true)
+		m.flattenTreeNodes(m.treeNodes, "", false, true)
 	} else {
 		m.flattenAncestorChain()
 	}
@@ -1,6 +1,11 @@
 package hierarchy
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 type keyMap struct {
 	showAllTree   key.Binding
@@ -8,29 +13,36 @@ type keyMap struct {
 	cursorDown    key.Binding
 	openDetails   key.Binding
 	openTimeframe key.Binding
+	goBack        key.Binding
 }
 
+var (
+	showAllTreeAction   = keymap.Register("hierarchy", "show_all", "Show full tree", "a")
+	openDetailsAction   = keymap.Register("hierarchy", "open_details", "Open goal details", "enter")
+	openTimeframeAction = keymap.Register("hierarchy", "open_timeframe", "Open timeframe", "o")
+)
+
 func newKeyMap() keyMap {
 	return keyMap{
-		showAllTree: key.NewBinding(
-			key.WithKeys("a", "ф"),
-			key.WithHelp("a", "Show full tree"),
-		),
-		cursorUp: key.NewBinding(
-			key.WithKeys("up", "k", "л"),
-			key.WithHelp("↑/k", "up"),
-		),
-		cursorDown: key.NewBinding(
-			key.WithKeys("down", "j", "о"),
-			key.WithHelp("↓/j", "down"),
-		),
-		openDetails: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "Open goal details"),
-		),
-		openTimeframe: key.NewBinding(
-			key.WithKeys("o", "щ"),
-			key.WithHelp("o", "Open timeframe"),
-		),
+		showAllTree:   showAllTreeAction.Binding(),
+		cursorUp:      screens.CursorUpKey(),
+		cursorDown:    screens.CursorDownKey(),
+		openDetails:   openDetailsAction.Binding(),
+		openTimeframe: openTimeframeAction.Binding(),
+		goBack:        screens.GoBackKey(),
+	}
+}
+
+// HelpKeys returns the bindings of the screen for the help overlay
+func (m *HierarchyScreen) HelpKeys() []screens.KeyGroup {
+	return []screens.KeyGroup{
+		{Title: "Hierarchy", Bindings: []key.Binding{
+			m.keys.cursorUp,
+			m.keys.cursorDown,
+			m.keys.showAllTree,
+			m.keys.openDetails,
+			m.keys.openTimeframe,
+			m.keys.goBack,
+		}},
 	}
 }
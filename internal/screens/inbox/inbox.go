@@ -0,0 +1,139 @@
+package inbox
+
+import (
+	"hinoki-cli/internal/goallist"
+	"hinoki-cli/internal/screens"
+	"hinoki-cli/internal/theme"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type InboxScreen struct {
+	list goallist.GoalList
+	keys keyMap
+
+	// capturePending starts a new goal once the inbox has loaded
+	capturePending bool
+	// returnAfterCapture goes back to the previous screen once the captured goal is saved
+	returnAfterCapture bool
+
+	width, height int
+}
+
+var (
+	headerStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.TextPrimary()).
+		MarginBottom(2).
+		PaddingTop(2)
+)
+
+const (
+	maxWidth = 130
+)
+
+// NewInboxScreen lists goals without a period. With capture set, it opens with
+// the new goal input and returns to the previous screen once the goal is saved
+func NewInboxScreen(capture bool) screens.Screen {
+	goalList := goallist.NewGoalList(nil, nil)
+	goalList.SetDisplayMode(goallist.Inbox)
+
+	return &InboxScreen{
+		list:               goalList,
+		keys:               newKeyMap(),
+		capturePending:     capture,
+		returnAfterCapture: capture,
+	}
+}
+
+func (m *InboxScreen) Init() tea.Cmd {
+	return m.list.Init()
+}
+
+func (m *InboxScreen) Update(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.list.IsInActiveState() {
+			if msg.Type == tea.KeyEsc {
+				// Cancelling the capture keeps the inbox open
+				m.returnAfterCapture = false
+			}
+			break
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.goBack):
+			return func() tea.Msg {
+				return screens.GoBack{}
+			}
+		case key.Matches(msg, m.keys.scheduleGoal):
+			m.list.StartDateChange("Schedule: ")
+			return nil
+		}
+	case goallist.AddGoalSuccess:
+		if m.returnAfterCapture {
+			return func() tea.Msg {
+				return screens.GoBack{}
+			}
+		}
+	case error:
+		// swallow errors in UI loop
+	}
+
+	cmds = append(cmds, m.list.Update(msg))
+
+	if _, ok := msg.(goallist.GoalsResult); ok && m.capturePending {
+		m.capturePending = false
+		m.list.StartNewGoal()
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func (m *InboxScreen) View() string {
+	header := headerStyle.Render("Inbox")
+
+	headerHeight := lipgloss.Height(header)
+	listHeight := m.height - headerHeight
+
+	style := lipgloss.NewStyle().PaddingLeft(2)
+	horizontalPadding := (m.width - maxWidth) / 2
+
+	if m.width > maxWidth {
+		style = style.PaddingLeft(horizontalPadding).PaddingRight(horizontalPadding)
+	}
+
+	contentWidth := min(m.width, maxWidth)
+	m.list.SetSize(contentWidth, listHeight)
+
+	view := lipgloss.JoinVertical(lipgloss.Left, header, m.list.View())
+
+	return style.
+		SetString(view).
+		Render()
+}
+
+func (m *InboxScreen) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// IsCapturingInput reports whether keys go to a text input, while a goal is typed
+func (m *InboxScreen) IsCapturingInput() bool {
+	return m.list.IsInActiveState()
+}
+
+func (m *InboxScreen) Refresh() tea.Cmd {
+	return m.list.RefreshData()
+}
+
+// Capture opens the new goal input, unless another input is open already
+func (m *InboxScreen) Capture() {
+	if !m.list.IsInActiveState() {
+		m.list.StartNewGoal()
+	}
+}
@@ -0,0 +1,30 @@
+package inbox
+
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	scheduleGoal key.Binding
+	goBack       key.Binding
+}
+
+var scheduleGoalAction = keymap.Register("inbox", "schedule", "Schedule goal", "s")
+
+func newKeyMap() keyMap {
+	return keyMap{
+		scheduleGoal: scheduleGoalAction.Binding(),
+		goBack:       screens.GoBackKey(),
+	}
+}
+
+// HelpKeys returns the bindings of the screen and its goal list for the help overlay
+func (m *InboxScreen) HelpKeys() []screens.KeyGroup {
+	return append(m.list.HelpKeys(), screens.KeyGroup{Title: "Inbox", Bindings: []key.Binding{
+		m.keys.scheduleGoal,
+		m.keys.goBack,
+	}})
+}
@@ -10,6 +10,7 @@ type Navigation interface {
 	Pop() Screen
 	Replace(screen Screen)
 	Top() Screen
+	Reset(screen Screen)
 }
 
 type NavigationState struct {
@@ -34,6 +35,28 @@ type OpenOverdueScreen struct{}
 type OpenHierarchyScreen struct {
 	Goal *goal.Goal
 }
+type OpenBackupsScreen struct{}
+type OpenArchiveScreen struct{}
+type OpenWorkspacesScreen struct{}
+type OpenDashboardScreen struct{}
+type OpenStatsScreen struct{}
+type OpenReviewScreen struct {
+	Timeframe goal.Timeframe
+	Date      time.Time
+}
+type OpenCalendarScreen struct {
+	Date time.Time
+}
+
+// WorkspaceSwitched is sent once another workspace database is open. Every screen is closed,
+// since they show goals of the previous workspace
+type WorkspaceSwitched struct {
+	Message string
+}
+type OpenInboxScreen struct {
+	// Capture opens the inbox with the new goal input, for quick capture from any screen
+	Capture bool
+}
 
 func (m *NavigationState) Push(screen Screen) {
 	m.stack = append(m.stack, screen)
@@ -51,6 +74,11 @@ func (m *NavigationState) Replace(screen Screen) {
 	m.stack = append(m.stack[:len(m.stack)-1], screen)
 }
 
+// Reset replaces the whole stack with screen
+func (m *NavigationState) Reset(screen Screen) {
+	m.stack = []Screen{screen}
+}
+
 func (m *NavigationState) Top() Screen {
 	if len(m.stack) == 0 {
 		return nil
@@ -1,21 +1,44 @@
 package overdue
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 type keyMap struct {
 	openGoal     key.Binding
 	assignParent key.Binding
+	rollForward  key.Binding
+	confirm      key.Binding
+	cancel       key.Binding
+	goBack       key.Binding
 }
 
+var (
+	openGoalAction     = keymap.Register("overdue", "open_goal", "Open goal in timeframe", "o")
+	assignParentAction = keymap.Register("overdue", "assign_parent", "Assign parent", "p")
+	rollForwardAction  = keymap.Register("overdue", "roll_forward", "Roll all overdue goals forward", "P")
+)
+
 func newKeyMap() keyMap {
 	return keyMap{
-		openGoal: key.NewBinding(
-			key.WithKeys("o", "щ"),
-			key.WithHelp("o", "Open goal in timeframe"),
-		),
-		assignParent: key.NewBinding(
-			key.WithKeys("p", "з"),
-			key.WithHelp("p", "Assign parent"),
-		),
+		openGoal:     openGoalAction.Binding(),
+		assignParent: assignParentAction.Binding(),
+		rollForward:  rollForwardAction.Binding(),
+		confirm:      screens.ConfirmKey(),
+		cancel:       screens.CancelKey(),
+		goBack:       screens.GoBackKey(),
 	}
 }
+
+// HelpKeys returns the bindings of the screen and its goal list for the help overlay
+func (m *OverdueScreen) HelpKeys() []screens.KeyGroup {
+	return append(m.list.HelpKeys(), screens.KeyGroup{Title: "Overdue", Bindings: []key.Binding{
+		m.keys.openGoal,
+		m.keys.assignParent,
+		m.keys.rollForward,
+		m.keys.goBack,
+	}})
+}
@@ -1,6 +1,7 @@
 package overdue
 
 import (
+	"fmt"
 	"time"
 
 	"hinoki-cli/internal/goal"
@@ -18,17 +19,32 @@ type OverdueScreen struct {
 	list goallist.GoalList
 	keys keyMap
 
+	// Overdue goals to roll forward once confirmed, nil while not asking
+	rollForwardPlan []repository.Postponement
+
 	width, height int
+
+	message string
 }
 
 var (
 	headerStyle = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(theme.TextPrimary()).
-		MarginBottom(2).
-		PaddingTop(2)
+			Bold(true).
+			Foreground(theme.TextPrimary()).
+			MarginBottom(2).
+			PaddingTop(2)
+
+	messageStyle = lipgloss.NewStyle().Foreground(theme.TextSecondary()).MarginTop(1).Italic(true)
 )
 
+type rollForwardPlanned struct {
+	plan []repository.Postponement
+}
+
+type rolledForward struct {
+	count int
+}
+
 const (
 	maxWidth = 130
 )
@@ -52,6 +68,9 @@ func (m *OverdueScreen) Update(msg tea.Msg) tea.Cmd {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.rollForwardPlan != nil {
+			return m.handleKeyMsgInConfirmState(msg)
+		}
 		if !m.list.IsInActiveState() {
 			cmd := m.handleKeyMsg(msg)
 			if cmd != nil {
@@ -60,8 +79,15 @@ func (m *OverdueScreen) Update(msg tea.Msg) tea.Cmd {
 		}
 	case goallist.AddGoalSuccess, goallist.UpdateGoalSuccess:
 		cmds = append(cmds, m.getOverdueGoalsCmd())
+	case rollForwardPlanned:
+		if len(msg.plan) > 0 {
+			m.rollForwardPlan = msg.plan
+		}
+	case rolledForward:
+		m.message = screens.RolledForwardMessage(msg.count)
+		cmds = append(cmds, m.getOverdueGoalsCmd())
 	case error:
-		// swallow errors in UI loop
+		m.message = fmt.Sprintf("❌ %v", msg)
 	}
 
 	// Always pass messages to the list so it can handle GoalsResult and other messages
@@ -73,8 +99,13 @@ func (m *OverdueScreen) Update(msg tea.Msg) tea.Cmd {
 func (m *OverdueScreen) View() string {
 	header := headerStyle.Render("Overdue Goals")
 
-	headerHeight := lipgloss.Height(header)
-	listHeight := m.height - headerHeight
+	listHeight := m.height - lipgloss.Height(header)
+
+	var message string
+	if m.message != "" {
+		message = messageStyle.Render(m.message)
+		listHeight -= lipgloss.Height(message)
+	}
 
 	style := lipgloss.NewStyle().PaddingLeft(2)
 	horizontalPadding := (m.width - maxWidth) / 2
@@ -87,8 +118,14 @@ func (m *OverdueScreen) View() string {
 	m.list.SetSize(contentWidth, listHeight)
 
 	body := m.list.View()
+	if m.rollForwardPlan != nil {
+		body = screens.RollForwardPreview(m.rollForwardPlan, contentWidth, listHeight)
+	}
 
 	view := lipgloss.JoinVertical(lipgloss.Left, header, body)
+	if message != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, message)
+	}
 
 	return style.
 		SetString(view).
@@ -100,6 +137,11 @@ func (m *OverdueScreen) SetSize(width, height int) {
 	m.height = height
 }
 
+// IsCapturingInput reports whether keys go to a text input, while a goal is typed
+func (m *OverdueScreen) IsCapturingInput() bool {
+	return m.list.IsInActiveState()
+}
+
 func (m *OverdueScreen) Refresh() tea.Cmd {
 	return m.getOverdueGoalsCmd()
 }
@@ -110,7 +152,7 @@ func (m *OverdueScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 	}
 
 	switch {
-	case msg.Type == tea.KeyEsc:
+	case key.Matches(msg, m.keys.goBack):
 		return func() tea.Msg {
 			return screens.GoBack{}
 		}
@@ -120,6 +162,9 @@ func (m *OverdueScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			return nil
 		}
 		return m.openGoalInTimeframeCmd(selectedGoal)
+	case key.Matches(msg, m.keys.rollForward):
+		m.message = ""
+		return m.planRollForwardCmd()
 	case key.Matches(msg, m.keys.assignParent):
 		selectedGoal := m.list.GetSelectedGoal()
 		if selectedGoal == nil {
@@ -140,6 +185,19 @@ func (m *OverdueScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+func (m *OverdueScreen) handleKeyMsgInConfirmState(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.confirm):
+		plan := m.rollForwardPlan
+		m.rollForwardPlan = nil
+		return m.rollForwardCmd(plan)
+	case key.Matches(msg, m.keys.cancel):
+		m.rollForwardPlan = nil
+	}
+
+	return nil
+}
+
 func (m *OverdueScreen) openGoalInTimeframeCmd(selectedGoal *goal.Goal) tea.Cmd {
 	return func() tea.Msg {
 		// Check if the goal has a timeframe and date
@@ -205,3 +263,24 @@ func (m *OverdueScreen) getOverdueGoalsCmd() tea.Cmd {
 		return goallist.GoalsResult{Goals: goals}
 	}
 }
+
+// planRollForwardCmd lists where every overdue goal moves
+func (m *OverdueScreen) planRollForwardCmd() tea.Cmd {
+	return func() tea.Msg {
+		goals, err := repository.GetOverdueGoals()
+		if err != nil {
+			return err
+		}
+		return rollForwardPlanned{plan: repository.PlanRollForward(goals, time.Now())}
+	}
+}
+
+func (m *OverdueScreen) rollForwardCmd(plan []repository.Postponement) tea.Cmd {
+	return func() tea.Msg {
+		count, err := repository.RollForward(plan)
+		if err != nil {
+			return err
+		}
+		return rolledForward{count: count}
+	}
+}
@@ -0,0 +1,62 @@
+package review
+
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	carryForward    key.Binding
+	reschedule      key.Binding
+	markDone        key.Binding
+	archive         key.Binding
+	skip            key.Binding
+	writeReflection key.Binding
+	finish          key.Binding
+	goBack          key.Binding
+}
+
+var (
+	carryForwardAction    = keymap.Register("review", "carry_forward", "Carry forward to next period", "c")
+	rescheduleAction      = keymap.Register("review", "reschedule", "Reschedule", "D")
+	markDoneAction        = keymap.Register("review", "mark_done", "Mark done", " ")
+	archiveAction         = keymap.Register("review", "archive", "Archive", "backspace")
+	skipAction            = keymap.Register("review", "skip", "Leave as is", "s")
+	writeReflectionAction = keymap.Register("review", "write_reflection", "Write reflection in $EDITOR", "E")
+	finishAction          = keymap.Register("review", "finish", "Finish and plan next period", "enter")
+)
+
+func newKeyMap() keyMap {
+	return keyMap{
+		carryForward:    carryForwardAction.Binding(),
+		reschedule:      rescheduleAction.Binding(),
+		markDone:        markDoneAction.Binding(),
+		archive:         archiveAction.Binding(),
+		skip:            skipAction.Binding(),
+		writeReflection: writeReflectionAction.Binding(),
+		finish:          finishAction.Binding(),
+		goBack:          screens.GoBackKey(),
+	}
+}
+
+// HelpKeys returns the bindings of the current step of the review for the help overlay
+func (m *ReviewScreen) HelpKeys() []screens.KeyGroup {
+	if m.step == reflectionStep {
+		return []screens.KeyGroup{{Title: "Reflection", Bindings: []key.Binding{
+			m.keys.writeReflection,
+			m.keys.finish,
+			m.keys.goBack,
+		}}}
+	}
+
+	return []screens.KeyGroup{{Title: "Review", Bindings: []key.Binding{
+		m.keys.carryForward,
+		m.keys.reschedule,
+		m.keys.markDone,
+		m.keys.archive,
+		m.keys.skip,
+		m.keys.goBack,
+	}}}
+}
@@ -0,0 +1,433 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/editor"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/repository"
+	"hinoki-cli/internal/screens"
+	"hinoki-cli/internal/theme"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type step int
+
+const (
+	goalsStep step = iota
+	reflectionStep
+)
+
+// outcome is what the review did with an unfinished goal
+type outcome int
+
+const (
+	carriedForward outcome = iota
+	rescheduled
+	markedDone
+	archived
+	skipped
+	outcomeCount
+)
+
+var outcomeNames = [outcomeCount]string{"carried forward", "rescheduled", "done", "archived", "left as is"}
+
+// ReviewScreen steps through the unfinished goals of a closing period, then asks for a
+// reflection on the period and opens the next one
+type ReviewScreen struct {
+	keys keyMap
+	step step
+
+	timeframe goal.Timeframe
+	date      time.Time
+
+	goals    []goal.Goal // Unfinished goals of the period, in list order
+	index    int         // Goal under review
+	loaded   bool
+	outcomes [outcomeCount]int
+
+	// busy is set while the goal under review is being updated, so that a key pressed
+	// again meanwhile doesn't apply to the same goal twice
+	busy bool
+
+	rescheduling bool
+	dateInput    textinput.Model
+
+	reflection string
+
+	width, height int
+
+	message string
+}
+
+var (
+	headerStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextPrimary()).
+			MarginBottom(2).
+			PaddingTop(2)
+
+	goalStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextPrimary())
+
+	mutedStyle = lipgloss.NewStyle().Foreground(theme.TextMuted())
+	keyStyle   = lipgloss.NewStyle().Foreground(theme.TextSecondary()).Width(12)
+	tagStyle   = lipgloss.NewStyle().Foreground(theme.Tag())
+	inputStyle = lipgloss.NewStyle().MarginTop(1).Foreground(theme.TextSecondary())
+
+	reflectionStyle = lipgloss.NewStyle().
+			Foreground(theme.TextSecondary()).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderLeft(true).
+			BorderForeground(theme.TextMuted()).
+			PaddingLeft(1).
+			MarginTop(1)
+
+	messageStyle = lipgloss.NewStyle().Foreground(theme.TextSecondary()).MarginTop(1).Italic(true)
+)
+
+const (
+	maxWidth = 130
+)
+
+// editorID tells the reflection apart from goal notes in editor.FinishedMsg
+const editorID = "review"
+
+type reviewLoaded struct {
+	goals      []goal.Goal
+	reflection string
+}
+
+type goalReviewed struct {
+	outcome outcome
+}
+
+type reflectionSaved struct {
+	text string
+}
+
+func NewReviewScreen(timeframe goal.Timeframe, date time.Time) screens.Screen {
+	dateInput := textinput.New()
+	dateInput.Prompt = "Reschedule: "
+	dateInput.Focus()
+
+	return &ReviewScreen{
+		keys:      newKeyMap(),
+		timeframe: timeframe,
+		date:      date,
+		dateInput: dateInput,
+	}
+}
+
+func (m *ReviewScreen) Init() tea.Cmd {
+	return m.loadCmd()
+}
+
+func (m *ReviewScreen) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.rescheduling {
+			return m.handleDateInputKeyMsg(msg)
+		}
+		return m.handleKeyMsg(msg)
+	case reviewLoaded:
+		m.goals = msg.goals
+		m.reflection = msg.reflection
+		m.loaded = true
+		m.nextGoal()
+	case goalReviewed:
+		m.busy = false
+		m.outcomes[msg.outcome]++
+		m.index++
+		m.nextGoal()
+	case editor.FinishedMsg:
+		if msg.ID != editorID {
+			return nil
+		}
+		if msg.Err != nil {
+			m.message = fmt.Sprintf("❌ %v", msg.Err)
+			return nil
+		}
+		return m.saveReflectionCmd(msg.Text)
+	case reflectionSaved:
+		m.reflection = msg.text
+		m.message = ""
+	case error:
+		m.busy = false
+		m.message = fmt.Sprintf("❌ %v", msg)
+	}
+
+	return nil
+}
+
+// nextGoal moves on to the reflection once every goal has been reviewed
+func (m *ReviewScreen) nextGoal() {
+	if m.index >= len(m.goals) {
+		m.step = reflectionStep
+	}
+}
+
+func (m *ReviewScreen) View() string {
+	title := "Review " + strings.ToLower(m.timeframe.String())
+	header := headerStyle.Render(title + "  " + mutedStyle.Render(dates.DateString(m.date, m.timeframe)))
+
+	var body string
+	switch {
+	case !m.loaded:
+		body = ""
+	case m.step == goalsStep:
+		body = m.goalView()
+	default:
+		body = m.reflectionView()
+	}
+
+	view := lipgloss.JoinVertical(lipgloss.Left, header, body)
+	if m.message != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, messageStyle.Render(m.message))
+	}
+
+	style := lipgloss.NewStyle().PaddingLeft(2)
+	horizontalPadding := (m.width - maxWidth) / 2
+
+	if m.width > maxWidth {
+		style = style.PaddingLeft(horizontalPadding).PaddingRight(horizontalPadding)
+	}
+
+	return style.
+		MaxWidth(m.width).
+		MaxHeight(m.height).
+		SetString(view).
+		Render()
+}
+
+func (m *ReviewScreen) goalView() string {
+	g := m.goals[m.index]
+
+	lines := []string{
+		mutedStyle.Render(fmt.Sprintf("Unfinished goal %d of %d", m.index+1, len(m.goals))),
+		"",
+		goalStyle.Render(g.Title),
+	}
+	if len(g.Tags) > 0 {
+		lines = append(lines, tagStyle.Render(goal.TitleWithTags("", g.Tags)))
+	}
+	if g.ParentTitle != nil {
+		lines = append(lines, mutedStyle.Render("↑ "+*g.ParentTitle))
+	}
+
+	next := m.nextPeriod()
+	lines = append(lines, "", actions(
+		action{m.keys.carryForward, "Carry forward to " + dates.DateString(next, m.timeframe)},
+		action{m.keys.reschedule, "Reschedule"},
+		action{m.keys.markDone, "Mark done"},
+		action{m.keys.archive, "Archive"},
+		action{m.keys.skip, "Leave as is"},
+	))
+
+	if m.rescheduling {
+		lines = append(lines, inputStyle.Render(m.dateInput.View()))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *ReviewScreen) reflectionView() string {
+	var summary []string
+	for o, count := range m.outcomes {
+		if count > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", count, outcomeNames[o]))
+		}
+	}
+
+	lines := []string{"Every goal of the period was done."}
+	if len(summary) > 0 {
+		lines = []string{"Unfinished goals: " + strings.Join(summary, ", ") + "."}
+	}
+
+	if m.reflection != "" {
+		lines = append(lines, reflectionStyle.Width(min(m.width, maxWidth)-4).Render(m.reflection))
+	} else {
+		lines = append(lines, "", mutedStyle.Render("How did the period go? What will you do differently in the next one?"))
+	}
+
+	next := m.nextPeriod()
+	lines = append(lines, "", actions(
+		action{m.keys.writeReflection, "Write reflection"},
+		action{m.keys.finish, "Plan " + dates.DateString(next, m.timeframe)},
+	))
+
+	return strings.Join(lines, "\n")
+}
+
+// action is a key offered by the current step of the review
+type action struct {
+	binding key.Binding
+	desc    string
+}
+
+func actions(list ...action) string {
+	lines := make([]string, len(list))
+	for i, a := range list {
+		lines[i] = keyStyle.Render(a.binding.Help().Key) + mutedStyle.Render(a.desc)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *ReviewScreen) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Refresh keeps the goals under review, since goals already reviewed have left the period
+func (m *ReviewScreen) Refresh() tea.Cmd {
+	return nil
+}
+
+// IsCapturingInput reports whether keys go to a text input, while a goal is rescheduled
+func (m *ReviewScreen) IsCapturingInput() bool {
+	return m.rescheduling
+}
+
+func (m *ReviewScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	if key.Matches(msg, m.keys.goBack) {
+		return func() tea.Msg {
+			return screens.GoBack{}
+		}
+	}
+
+	if !m.loaded || m.busy {
+		return nil
+	}
+
+	if m.step == reflectionStep {
+		switch {
+		case key.Matches(msg, m.keys.writeReflection):
+			return editor.Edit(editorID, m.reflection)
+		case key.Matches(msg, m.keys.finish):
+			next := m.nextPeriod()
+			return func() tea.Msg {
+				return screens.OpenTimeframeScreenWithGoal{Timeframe: m.timeframe, Date: next}
+			}
+		}
+		return nil
+	}
+
+	g := m.goals[m.index]
+
+	switch {
+	case key.Matches(msg, m.keys.carryForward):
+		return m.carryForwardCmd(g)
+	case key.Matches(msg, m.keys.reschedule):
+		m.dateInput.SetValue("")
+		m.rescheduling = true
+	case key.Matches(msg, m.keys.markDone):
+		g.IsDone = true
+		return m.updateGoalCmd(g, markedDone)
+	case key.Matches(msg, m.keys.archive):
+		g.IsArchived = true
+		return m.updateGoalCmd(g, archived)
+	case key.Matches(msg, m.keys.skip):
+		m.busy = true
+		return func() tea.Msg {
+			return goalReviewed{outcome: skipped}
+		}
+	}
+
+	return nil
+}
+
+func (m *ReviewScreen) handleDateInputKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.rescheduling = false
+	case tea.KeyEnter:
+		date, timeframe, err := dates.ParseDate(time.Now(), m.dateInput.Value())
+		if err != nil {
+			m.message = fmt.Sprintf("❌ %v", err)
+			return nil
+		}
+
+		m.rescheduling = false
+		m.message = ""
+
+		g := m.goals[m.index]
+		g.Date = &date
+		g.Timeframe = &timeframe
+		return m.updateGoalCmd(g, rescheduled)
+	default:
+		var cmd tea.Cmd
+		m.dateInput, cmd = m.dateInput.Update(msg)
+		return cmd
+	}
+
+	return nil
+}
+
+func (m *ReviewScreen) loadCmd() tea.Cmd {
+	timeframe, date := m.timeframe, m.date
+	return func() tea.Msg {
+		goals, err := repository.GetGoalsByDate(timeframe, date)
+		if err != nil {
+			return err
+		}
+
+		var unfinished []goal.Goal
+		for _, g := range goals {
+			if !g.IsDone {
+				unfinished = append(unfinished, g)
+			}
+		}
+
+		reflection, err := repository.GetPeriodNote(timeframe, date)
+		if err != nil {
+			return err
+		}
+
+		return reviewLoaded{goals: unfinished, reflection: reflection}
+	}
+}
+
+func (m *ReviewScreen) updateGoalCmd(g goal.Goal, o outcome) tea.Cmd {
+	m.busy = true
+	return func() tea.Msg {
+		if err := repository.UpdateGoal(g); err != nil {
+			return fmt.Errorf("failed to update goal: %w", err)
+		}
+		return goalReviewed{outcome: o}
+	}
+}
+
+// nextPeriod returns the period goals are carried forward to: the one after the reviewed period,
+// or the current one when reviewing a period further back
+func (m *ReviewScreen) nextPeriod() time.Time {
+	return dates.RollForward(m.date, m.timeframe, time.Now())
+}
+
+// carryForwardCmd rolls the goal forward like a bulk roll-forward would, counting the postponement
+func (m *ReviewScreen) carryForwardCmd(g goal.Goal) tea.Cmd {
+	m.busy = true
+	return func() tea.Msg {
+		if _, err := repository.RollForward(repository.PlanRollForward([]goal.Goal{g}, time.Now())); err != nil {
+			return fmt.Errorf("failed to carry goal forward: %w", err)
+		}
+		return goalReviewed{outcome: carriedForward}
+	}
+}
+
+func (m *ReviewScreen) saveReflectionCmd(text string) tea.Cmd {
+	timeframe, date := m.timeframe, m.date
+	return func() tea.Msg {
+		if err := repository.SavePeriodNote(timeframe, date, text); err != nil {
+			return fmt.Errorf("failed to save reflection: %w", err)
+		}
+		return reflectionSaved{text: text}
+	}
+}
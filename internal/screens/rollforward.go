@@ -0,0 +1,57 @@
+package screens
+
+import (
+	"fmt"
+
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/repository"
+	"hinoki-cli/internal/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	previewTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(theme.TextPrimary()).
+				MarginBottom(1)
+
+	previewGoalStyle = lipgloss.NewStyle().Foreground(theme.TextPrimary())
+	previewMetaStyle = lipgloss.NewStyle().Foreground(theme.TextMuted())
+)
+
+// RollForwardPreview renders where the goals of a roll-forward move, asking to confirm it.
+// Goals that don't fit in height are summed up on the last line
+func RollForwardPreview(plan []repository.Postponement, width, height int) string {
+	lines := []string{previewTitleStyle.Render(fmt.Sprintf("Roll %d unfinished %s forward? (y/n)", len(plan), goalsNoun(len(plan))))}
+
+	// Every goal takes its title and a line with the move
+	fits := max((height-lipgloss.Height(lines[0])-1)/2, 1)
+	for i, p := range plan {
+		if i == fits && len(plan) > fits {
+			lines = append(lines, previewMetaStyle.Render(fmt.Sprintf("… and %d more", len(plan)-fits)))
+			break
+		}
+
+		timeframe := *p.Goal.Timeframe
+		move := fmt.Sprintf("%s • %s → %s", timeframe.String(), dates.DateString(*p.Goal.Date, timeframe), dates.DateString(p.Date, timeframe))
+		lines = append(lines,
+			previewGoalStyle.Render(p.Goal.Title),
+			previewMetaStyle.Render(move),
+		)
+	}
+
+	return lipgloss.NewStyle().MaxWidth(width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// RolledForwardMessage reports a roll-forward of count goals
+func RolledForwardMessage(count int) string {
+	return fmt.Sprintf("✅ Rolled %d %s forward", count, goalsNoun(count))
+}
+
+func goalsNoun(count int) string {
+	if count == 1 {
+		return "goal"
+	}
+	return "goals"
+}
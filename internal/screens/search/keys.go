@@ -1,7 +1,47 @@
 package search
 
-type keyMap struct{}
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
 
-func newKeyMap() keyMap {
-	return keyMap{}
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	selectGoal key.Binding
+	goBack     key.Binding
+}
+
+var selectGoalAction = keymap.Register("search", "select", "Open goal in timeframe", "enter")
+
+// newKeyMap returns the keys of the screen. The selected goal is opened,
+// or assigned as parent if search was opened for that
+func newKeyMap(assigningParent bool) keyMap {
+	keys := keyMap{
+		selectGoal: selectGoalAction.Binding(),
+		goBack:     screens.GoBackKey(),
+	}
+
+	if assigningParent {
+		keys.selectGoal.SetHelp(keys.selectGoal.Help().Key, "Assign as parent")
+	}
+
+	return keys
+}
+
+// HelpKeys returns the bindings of the screen for the help overlay
+func (m *SearchScreen) HelpKeys() []screens.KeyGroup {
+	return []screens.KeyGroup{
+		{Title: "Search", Bindings: []key.Binding{
+			m.searchList.KeyMap.CursorUp,
+			m.searchList.KeyMap.CursorDown,
+			m.keys.selectGoal,
+			m.keys.goBack,
+		}},
+	}
+}
+
+// IsCapturingInput reports true, since everything typed on the screen goes to the search query
+func (m *SearchScreen) IsCapturingInput() bool {
+	return true
 }
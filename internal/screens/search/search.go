@@ -37,6 +37,7 @@ const (
 
 type searchGoalsResult struct {
 	goals []goal.Goal
+	notes []repository.PeriodNote
 }
 
 func NewSearchScreen() screens.Screen {
@@ -49,7 +50,7 @@ func NewSearchScreenForParentAssignment(goalID string) screens.Screen {
 	if goalID != "" {
 		searchInput.Placeholder = "Type to find parent goal..."
 	} else {
-		searchInput.Placeholder = "Type to find goals..."
+		searchInput.Placeholder = "Type to find goals and journals, add #tags to filter..."
 	}
 	searchInput.CharLimit = 256
 	searchInput.Focus()
@@ -74,7 +75,7 @@ func NewSearchScreenForParentAssignment(goalID string) screens.Screen {
 	return &SearchScreen{
 		searchInput:          searchInput,
 		searchList:           searchList,
-		keys:                 newKeyMap(),
+		keys:                 newKeyMap(goalID != ""),
 		assignParentToGoalID: goalID,
 	}
 }
@@ -88,8 +89,8 @@ func (m *SearchScreen) Update(msg tea.Msg) tea.Cmd {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Handle Enter and Esc first, before list can consume them
-		if msg.Type == tea.KeyEnter || msg.Type == tea.KeyEsc {
+		// Handle selecting and going back first, before list can consume them
+		if key.Matches(msg, m.keys.selectGoal, m.keys.goBack) {
 			cmd := m.handleKeyMsg(msg)
 			if cmd != nil {
 				cmds = append(cmds, cmd)
@@ -178,12 +179,12 @@ func (m *SearchScreen) Refresh() tea.Cmd {
 }
 
 func (m *SearchScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
-	switch msg.Type {
-	case tea.KeyEsc:
+	switch {
+	case key.Matches(msg, m.keys.goBack):
 		return func() tea.Msg {
 			return screens.GoBack{}
 		}
-	case tea.KeyEnter:
+	case key.Matches(msg, m.keys.selectGoal):
 		// If list has items, open selected goal
 		if len(m.searchList.Items()) > 0 {
 			return m.openSelectedGoal()
@@ -209,6 +210,12 @@ func (m *SearchScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *SearchScreen) openSelectedGoal() tea.Cmd {
+	if item, ok := m.searchList.SelectedItem().(noteItem); ok {
+		return func() tea.Msg {
+			return screens.OpenTimeframeScreenWithGoal{Timeframe: item.note.Timeframe, Date: item.note.Start}
+		}
+	}
+
 	item, ok := m.searchList.SelectedItem().(searchItem)
 	if !ok {
 		return nil
@@ -279,12 +286,30 @@ func (m *SearchScreen) searchGoalsCmd(term string) tea.Cmd {
 		return nil
 	}
 
+	// #tags in the query limit results to goals with any of those tags
+	text, tags := goal.ParseTags(trimmed)
+	if text == "" && len(tags) == 0 {
+		m.searchList.SetItems([]list.Item{})
+		return nil
+	}
+
+	// Period journals have no tags, and can't be parents
+	searchNotes := len(tags) == 0 && m.assignParentToGoalID == ""
+
 	return func() tea.Msg {
-		goals, err := repository.SearchGoals(trimmed, 50)
+		goals, err := repository.SearchGoals(text, tags, 50)
 		if err != nil {
 			return err
 		}
-		return searchGoalsResult{goals: goals}
+
+		var notes []repository.PeriodNote
+		if searchNotes {
+			if notes, err = repository.SearchPeriodNotes(text, 20); err != nil {
+				return err
+			}
+		}
+
+		return searchGoalsResult{goals: goals, notes: notes}
 	}
 }
 
@@ -297,5 +322,8 @@ func (m *SearchScreen) handleSearchGoals(msg searchGoalsResult) {
 		}
 		items = append(items, searchItem{goal: g})
 	}
+	for _, note := range msg.notes {
+		items = append(items, noteItem{note: note})
+	}
 	m.searchList.SetItems(items)
 }
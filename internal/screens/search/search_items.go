@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"hinoki-cli/internal/dates"
 	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/repository"
 	"hinoki-cli/internal/theme"
 	"io"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,11 +22,20 @@ func (i searchItem) FilterValue() string {
 	return i.goal.Title
 }
 
+// noteItem is a period journal matching the search
+type noteItem struct {
+	note repository.PeriodNote
+}
+
+func (i noteItem) FilterValue() string {
+	return i.note.Text
+}
+
 type searchItemDelegate struct{}
 
 var (
 	searchMetaStyle     = lipgloss.NewStyle().Foreground(theme.TextMuted())
-	searchSelectedStyle = lipgloss.NewStyle().Foreground(theme.TextSelected())
+	searchSelectedStyle = theme.SelectedStyle()
 )
 
 func newSearchItemDelegate() list.ItemDelegate {
@@ -40,16 +51,20 @@ func (d searchItemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 }
 
 func (d searchItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
-	item, ok := listItem.(searchItem)
-	if !ok {
+	var line, meta string
+	switch item := listItem.(type) {
+	case searchItem:
+		line = item.goal.Title
+		meta = d.metaLine(item.goal)
+	case noteItem:
+		line, _, _ = strings.Cut(item.note.Text, "\n")
+		meta = fmt.Sprintf("%s journal • %s", item.note.Timeframe.String(), dates.DateString(item.note.Start, item.note.Timeframe))
+	default:
 		return
 	}
 
 	itemStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary())
 
-	meta := d.metaLine(item.goal)
-
-	line := item.goal.Title
 	if meta != "" {
 		line = fmt.Sprintf("%s\n%s", line, searchMetaStyle.Render(meta))
 	}
@@ -64,7 +79,7 @@ func (d searchItemDelegate) Render(w io.Writer, m list.Model, index int, listIte
 
 func (d searchItemDelegate) metaLine(goal goal.Goal) string {
 	if goal.Timeframe == nil {
-		return ""
+		return tagsString(goal.Tags)
 	}
 
 	timeframe := goal.Timeframe.String()
@@ -82,5 +97,17 @@ func (d searchItemDelegate) metaLine(goal goal.Goal) string {
 		meta = fmt.Sprintf("%s • Parent: %s", meta, *goal.ParentTitle)
 	}
 
+	if len(goal.Tags) > 0 {
+		meta = fmt.Sprintf("%s • %s", meta, tagsString(goal.Tags))
+	}
+
 	return meta
 }
+
+func tagsString(tags []string) string {
+	var parts []string
+	for _, tag := range tags {
+		parts = append(parts, "#"+tag)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,42 @@
+package statistics
+
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	morePeriods  key.Binding
+	fewerPeriods key.Binding
+	reload       key.Binding
+	goBack       key.Binding
+}
+
+var (
+	morePeriodsAction  = keymap.Register("stats", "more_periods", "More periods", "+", "=")
+	fewerPeriodsAction = keymap.Register("stats", "fewer_periods", "Fewer periods", "-")
+	reloadAction       = keymap.Register("stats", "reload", "Reload statistics", "r")
+)
+
+func newKeyMap() keyMap {
+	return keyMap{
+		morePeriods:  morePeriodsAction.Binding(),
+		fewerPeriods: fewerPeriodsAction.Binding(),
+		reload:       reloadAction.Binding(),
+		goBack:       screens.GoBackKey(),
+	}
+}
+
+// HelpKeys returns the bindings of the screen for the help overlay
+func (m *StatisticsScreen) HelpKeys() []screens.KeyGroup {
+	return []screens.KeyGroup{
+		{Title: "Statistics", Bindings: []key.Binding{
+			m.keys.morePeriods,
+			m.keys.fewerPeriods,
+			m.keys.reload,
+			m.keys.goBack,
+		}},
+	}
+}
@@ -0,0 +1,205 @@
+package statistics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"hinoki-cli/internal/screens"
+	"hinoki-cli/internal/stats"
+	"hinoki-cli/internal/theme"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type StatisticsScreen struct {
+	keys    keyMap
+	periods int
+	report  *stats.Report
+
+	width, height int
+
+	message string
+}
+
+var (
+	headerStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextPrimary()).
+			MarginBottom(2).
+			PaddingTop(2)
+
+	sectionStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextSecondary()).
+			MarginTop(1)
+
+	labelStyle   = lipgloss.NewStyle().Foreground(theme.TextPrimary()).Width(10)
+	mutedStyle   = lipgloss.NewStyle().Foreground(theme.TextMuted())
+	doneStyle    = lipgloss.NewStyle().Foreground(theme.Done())
+	overdueStyle = lipgloss.NewStyle().Foreground(theme.Overdue())
+	barStyle     = lipgloss.NewStyle().Foreground(theme.TextSelected())
+	messageStyle = lipgloss.NewStyle().Foreground(theme.TextSecondary()).MarginTop(1).Italic(true)
+)
+
+const (
+	maxWidth = 130
+
+	maxPeriods = 52
+	barWidth   = 20
+)
+
+type statsResult struct {
+	report stats.Report
+}
+
+func NewStatisticsScreen() screens.Screen {
+	return &StatisticsScreen{
+		keys:    newKeyMap(),
+		periods: stats.DefaultPeriods,
+	}
+}
+
+func (m *StatisticsScreen) Init() tea.Cmd {
+	return m.getStatsCmd()
+}
+
+func (m *StatisticsScreen) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKeyMsg(msg)
+	case statsResult:
+		// Results of a number of periods that was passed while changing it quickly are dropped
+		if msg.report.Periods == m.periods {
+			m.report = &msg.report
+			m.message = ""
+		}
+	case error:
+		m.message = fmt.Sprintf("❌ %v", msg)
+	}
+
+	return nil
+}
+
+func (m *StatisticsScreen) View() string {
+	header := headerStyle.Render("Statistics" + mutedStyle.Render(fmt.Sprintf("  last %d periods", m.periods)))
+
+	style := lipgloss.NewStyle().PaddingLeft(2)
+	horizontalPadding := (m.width - maxWidth) / 2
+
+	if m.width > maxWidth {
+		style = style.PaddingLeft(horizontalPadding).PaddingRight(horizontalPadding)
+	}
+
+	view := header
+	if m.report != nil {
+		view = lipgloss.JoinVertical(lipgloss.Left, header, m.reportView(*m.report))
+	}
+
+	if m.message != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, messageStyle.Render(m.message))
+	}
+
+	return style.
+		MaxHeight(m.height).
+		SetString(view).
+		Render()
+}
+
+func (m *StatisticsScreen) reportView(report stats.Report) string {
+	var lines []string
+
+	lines = append(lines, sectionStyle.UnsetMarginTop().Render("Completion"), mutedStyle.Render("Done goals per period, oldest first"))
+	for _, c := range report.Completion {
+		done, total := c.Sum()
+		lines = append(lines, labelStyle.Render(c.Timeframe.String())+
+			doneStyle.Render(stats.Sparkline(c.Values(), 1))+"  "+
+			mutedStyle.Render(percent(done, total)))
+	}
+
+	lines = append(lines,
+		sectionStyle.Render("Completed days"),
+		labelStyle.Render("Current")+days(report.Streaks.Current),
+		labelStyle.Render("Longest")+days(report.Streaks.Longest),
+	)
+
+	values, top := report.OverdueValues()
+	now := report.Overdue[len(report.Overdue)-1].Count
+	lines = append(lines,
+		sectionStyle.Render("Overdue goals"),
+		mutedStyle.Render("At the end of every week, oldest first"),
+		labelStyle.Render("Weeks")+overdueStyle.Render(stats.Sparkline(values, top))+"  "+mutedStyle.Render(fmt.Sprintf("%d now", now)),
+	)
+
+	lines = append(lines, sectionStyle.Render("Tracing up to a life goal"))
+	for _, a := range report.LifeAlignment {
+		filled := 0
+		if a.Total > 0 {
+			filled = a.Linked * barWidth / a.Total
+		}
+		bar := barStyle.Render(strings.Repeat("█", filled)) + mutedStyle.Render(strings.Repeat("░", barWidth-filled))
+		lines = append(lines, labelStyle.Render(a.Timeframe.String())+bar+"  "+mutedStyle.Render(percent(a.Linked, a.Total)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// percent formats part of total as e.g. "62% (31/50)"
+func percent(part, total int) string {
+	if total == 0 {
+		return "no goals"
+	}
+	return fmt.Sprintf("%d%% (%d/%d)", part*100/total, part, total)
+}
+
+func days(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
+
+func (m *StatisticsScreen) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *StatisticsScreen) Refresh() tea.Cmd {
+	return m.getStatsCmd()
+}
+
+func (m *StatisticsScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.goBack):
+		return func() tea.Msg {
+			return screens.GoBack{}
+		}
+	case key.Matches(msg, m.keys.morePeriods):
+		if m.periods < maxPeriods {
+			m.periods++
+			return m.getStatsCmd()
+		}
+	case key.Matches(msg, m.keys.fewerPeriods):
+		if m.periods > 1 {
+			m.periods--
+			return m.getStatsCmd()
+		}
+	case key.Matches(msg, m.keys.reload):
+		return m.getStatsCmd()
+	}
+
+	return nil
+}
+
+func (m *StatisticsScreen) getStatsCmd() tea.Cmd {
+	periods := m.periods
+	return func() tea.Msg {
+		report, err := stats.Load(time.Now(), periods)
+		if err != nil {
+			return fmt.Errorf("failed to load statistics: %w", err)
+		}
+		return statsResult{report: report}
+	}
+}
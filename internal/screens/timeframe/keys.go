@@ -1,6 +1,11 @@
 package timeframe
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 type listKeyMap struct {
 	dayTimeslice     key.Binding
@@ -18,69 +23,130 @@ type listKeyMap struct {
 	unlinkParent     key.Binding
 	openOverdue      key.Binding
 	createBackup     key.Binding
+	openBackups      key.Binding
+	filterTags       key.Binding
+	openInbox        key.Binding
+	openArchive      key.Binding
+	openWorkspaces   key.Binding
+	openCalendar     key.Binding
+	openDashboard    key.Binding
+	openStats        key.Binding
+	reviewPeriod     key.Binding
+	editJournal      key.Binding
+	toggleJournal    key.Binding
+	rollForward      key.Binding
+	confirm          key.Binding
+	cancel           key.Binding
 }
 
+var (
+	dayTimesliceAction     = keymap.Register("timeframe", "day", "Day timeframe", "d")
+	weekTimesliceAction    = keymap.Register("timeframe", "week", "Week timeframe", "w")
+	monthTimesliceAction   = keymap.Register("timeframe", "month", "Month timeframe", "m")
+	quarterTimesliceAction = keymap.Register("timeframe", "quarter", "Quarter timeframe", "q")
+	yearTimesliceAction    = keymap.Register("timeframe", "year", "Year timeframe", "y")
+	lifeTimesliceAction    = keymap.Register("timeframe", "life", "Life timeframe", "L")
+	nextPeriodAction       = keymap.Register("timeframe", "next_period", "Next period", "right", "l")
+	previousPeriodAction   = keymap.Register("timeframe", "previous_period", "Previous period", "left", "h")
+	currentPeriodAction    = keymap.Register("timeframe", "current_period", "Current period", "t")
+	gotoPeriodAction       = keymap.Register("timeframe", "goto_period", "Go to period", "g")
+	searchGoalsAction      = keymap.Register("timeframe", "search", "Search goals", "f", "/")
+	goToParentAction       = keymap.Register("timeframe", "go_to_parent", "Go to parent goal", "p")
+	unlinkParentAction     = keymap.Register("timeframe", "unlink_parent", "Unlink from parent", "u")
+	openOverdueAction      = keymap.Register("timeframe", "open_overdue", "Open overdue goals", "o")
+	createBackupAction     = keymap.Register("timeframe", "create_backup", "Create database backup", "B")
+	openBackupsAction      = keymap.Register("timeframe", "open_backups", "Browse backups", "b")
+	filterTagsAction       = keymap.Register("timeframe", "filter_tags", "Filter by tags", "#")
+	openInboxAction        = keymap.Register("timeframe", "open_inbox", "Open inbox", "i")
+	openArchiveAction      = keymap.Register("timeframe", "open_archive", "Open archive", "A")
+	openWorkspacesAction   = keymap.Register("timeframe", "open_workspaces", "Switch workspace", "W")
+	openCalendarAction     = keymap.Register("timeframe", "open_calendar", "Open calendar", "c")
+	openDashboardAction    = keymap.Register("timeframe", "open_dashboard", "Open dashboard", "a")
+	openStatsAction        = keymap.Register("timeframe", "open_stats", "Open statistics", "s")
+	reviewPeriodAction     = keymap.Register("timeframe", "review_period", "Review period", "V")
+	editJournalAction      = keymap.Register("timeframe", "edit_journal", "Edit period journal in $EDITOR", "E")
+	toggleJournalAction    = keymap.Register("timeframe", "toggle_journal", "Collapse or expand journal", "J")
+	rollForwardAction      = keymap.Register("timeframe", "roll_forward", "Roll unfinished goals forward", "P")
+)
+
 func NewListKeyMap() listKeyMap {
 	return listKeyMap{
-		dayTimeslice: key.NewBinding(
-			key.WithKeys("d", "в"),
-			key.WithHelp("d", "Day timeframe"),
-		),
-		weekTimeslice: key.NewBinding(
-			key.WithKeys("w", "ц"),
-			key.WithHelp("w", "Week timeframe"),
-		),
-		monthTimeslice: key.NewBinding(
-			key.WithKeys("m", "ь"),
-			key.WithHelp("m", "Month timeframe"),
-		),
-		quarterTimeslice: key.NewBinding(
-			key.WithKeys("q", "й"),
-			key.WithHelp("q", "Quarter timeframe"),
-		),
-		yearTimeslice: key.NewBinding(
-			key.WithKeys("y", "н"),
-			key.WithHelp("y", "Year timeframe"),
-		),
-		lifeTimeslice: key.NewBinding(
-			key.WithKeys("L", "Д"),
-			key.WithHelp("L", "Life timeframe"),
-		),
-		nextPeriod: key.NewBinding(
-			key.WithKeys("right", "l", "д"),
-			key.WithHelp("->", "Next period"),
-		),
-		previousPeriod: key.NewBinding(
-			key.WithKeys("left", "h", "р"),
-			key.WithHelp("<-", "Previous period"),
-		),
-		currentPeriod: key.NewBinding(
-			key.WithKeys("t", "е"),
-			key.WithHelp("t", "Current period"),
-		),
-		gotoPeriod: key.NewBinding(
-			key.WithKeys("g", "п"),
-			key.WithHelp("g", "Go to period"),
-		),
-		searchGoals: key.NewBinding(
-			key.WithKeys("f", "/"),
-			key.WithHelp("f", "Search goals"),
-		),
-		goToParent: key.NewBinding(
-			key.WithKeys("p", "з"),
-			key.WithHelp("p", "Go to parent goal"),
-		),
-		unlinkParent: key.NewBinding(
-			key.WithKeys("u", "г"),
-			key.WithHelp("u", "Unlink from parent"),
-		),
-		openOverdue: key.NewBinding(
-			key.WithKeys("o", "щ"),
-			key.WithHelp("o", "Open overdue goals"),
-		),
-		createBackup: key.NewBinding(
-			key.WithKeys("B"),
-			key.WithHelp("B", "Create database backup"),
-		),
+		dayTimeslice:     dayTimesliceAction.Binding(),
+		weekTimeslice:    weekTimesliceAction.Binding(),
+		monthTimeslice:   monthTimesliceAction.Binding(),
+		quarterTimeslice: quarterTimesliceAction.Binding(),
+		yearTimeslice:    yearTimesliceAction.Binding(),
+		lifeTimeslice:    lifeTimesliceAction.Binding(),
+		nextPeriod:       nextPeriodAction.Binding(),
+		previousPeriod:   previousPeriodAction.Binding(),
+		currentPeriod:    currentPeriodAction.Binding(),
+		gotoPeriod:       gotoPeriodAction.Binding(),
+		searchGoals:      searchGoalsAction.Binding(),
+		goToParent:       goToParentAction.Binding(),
+		unlinkParent:     unlinkParentAction.Binding(),
+		openOverdue:      openOverdueAction.Binding(),
+		createBackup:     createBackupAction.Binding(),
+		openBackups:      openBackupsAction.Binding(),
+		filterTags:       filterTagsAction.Binding(),
+		openInbox:        openInboxAction.Binding(),
+		openArchive:      openArchiveAction.Binding(),
+		openWorkspaces:   openWorkspacesAction.Binding(),
+		openCalendar:     openCalendarAction.Binding(),
+		openDashboard:    openDashboardAction.Binding(),
+		openStats:        openStatsAction.Binding(),
+		reviewPeriod:     reviewPeriodAction.Binding(),
+		editJournal:      editJournalAction.Binding(),
+		toggleJournal:    toggleJournalAction.Binding(),
+		rollForward:      rollForwardAction.Binding(),
+		confirm:          screens.ConfirmKey(),
+		cancel:           screens.CancelKey(),
 	}
 }
+
+// HelpKeys returns the bindings of the screen and its goal list for the help overlay
+func (m *TimeframeScreen) HelpKeys() []screens.KeyGroup {
+	groups := []screens.KeyGroup{
+		{Title: "Periods", Bindings: []key.Binding{
+			m.keys.dayTimeslice,
+			m.keys.weekTimeslice,
+			m.keys.monthTimeslice,
+			m.keys.quarterTimeslice,
+			m.keys.yearTimeslice,
+			m.keys.lifeTimeslice,
+			m.keys.previousPeriod,
+			m.keys.nextPeriod,
+			m.keys.currentPeriod,
+			m.keys.gotoPeriod,
+			m.keys.reviewPeriod,
+			m.keys.rollForward,
+		}},
+		{Title: "Journal", Bindings: []key.Binding{
+			m.keys.editJournal,
+			m.keys.toggleJournal,
+		}},
+	}
+
+	groups = append(groups, m.list.HelpKeys()...)
+
+	return append(groups,
+		screens.KeyGroup{Title: "Parents", Bindings: []key.Binding{
+			m.keys.goToParent,
+			m.keys.unlinkParent,
+		}},
+		screens.KeyGroup{Title: "Find", Bindings: []key.Binding{
+			m.keys.searchGoals,
+			m.keys.filterTags,
+		}},
+		screens.KeyGroup{Title: "Screens", Bindings: []key.Binding{
+			m.keys.openInbox,
+			m.keys.openDashboard,
+			m.keys.openCalendar,
+			m.keys.openStats,
+			m.keys.openOverdue,
+			m.keys.openArchive,
+			m.keys.openBackups,
+			m.keys.createBackup,
+			m.keys.openWorkspaces,
+		}},
+	)
+}
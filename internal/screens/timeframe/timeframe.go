@@ -2,10 +2,13 @@ package timeframe
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"hinoki-cli/internal/config"
 	"hinoki-cli/internal/dates"
 	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/editor"
 	"hinoki-cli/internal/goal"
 	"hinoki-cli/internal/goallist"
 	"hinoki-cli/internal/repository"
@@ -21,6 +24,8 @@ import (
 const (
 	Normal = iota
 	GotoDate
+	FilterTags
+	ConfirmRollForward
 )
 
 type State int
@@ -34,6 +39,16 @@ type TimeframeScreen struct {
 	date      time.Time
 	timeframe goal.Timeframe
 
+	// Journal of the shown period, shown under the header unless collapsed
+	journal          string
+	journalCollapsed bool
+
+	// Goals of the period to roll forward once confirmed
+	rollForwardPlan []repository.Postponement
+
+	// Name of the open workspace, shown in the header
+	workspace string
+
 	width, height int
 
 	// Temporary message to display (e.g., backup success/error)
@@ -44,12 +59,25 @@ type TimeframeScreen struct {
 var (
 	actionInputLightStyle = lipgloss.NewStyle().MarginBottom(1).Foreground(theme.TextSecondary())
 	actionInputDarkStyle  = lipgloss.NewStyle().MarginBottom(1).Foreground(theme.TextSecondary())
+
+	journalTitleStyle = lipgloss.NewStyle().Foreground(theme.TextMuted())
+	journalStyle      = lipgloss.NewStyle().
+				Foreground(theme.TextSecondary()).
+				BorderStyle(lipgloss.NormalBorder()).
+				BorderLeft(true).
+				BorderForeground(theme.TextMuted()).
+				PaddingLeft(1)
 )
 
 const (
 	maxWidth = 130
+	// The journal never takes more than this share of the screen height, the rest is for goals
+	maxJournalHeightRatio = 4
 )
 
+// journalEditorID tells the journal apart from goal notes in editor.FinishedMsg
+const journalEditorID = "journal"
+
 type GoalsResult struct {
 	goals []goal.Goal
 }
@@ -57,6 +85,27 @@ type GoalsResult struct {
 type AddGoalSuccess struct{}
 type UpdateGoalSuccess struct{}
 
+// journalResult is the journal of the period of a timeframe starting on period
+type journalResult struct {
+	timeframe goal.Timeframe
+	period    string
+	text      string
+}
+
+type rollForwardPlanned struct {
+	plan []repository.Postponement
+}
+
+type rolledForward struct {
+	count int
+}
+
+// actionError reports an action of the screen that failed, such as saving the journal
+type actionError struct {
+	action string
+	err    error
+}
+
 type BackupSuccess struct {
 	Path string
 }
@@ -72,6 +121,9 @@ func NewTimeframeScreen() screens.Screen {
 	actionInput.Focus()
 
 	timeframe := goal.Day
+	if startup, err := config.GetStartupSettings(); err == nil {
+		timeframe = startup.Timeframe
+	}
 	date := time.Now()
 
 	goalList := goallist.NewGoalList(&timeframe, &date)
@@ -82,6 +134,7 @@ func NewTimeframeScreen() screens.Screen {
 		list:        goalList,
 		timeframe:   timeframe,
 		date:        date,
+		workspace:   db.Workspace(),
 	}
 }
 
@@ -96,7 +149,7 @@ func (m *TimeframeScreen) SetSelectedGoalID(goalID string) {
 }
 
 func (m *TimeframeScreen) Init() tea.Cmd {
-	return m.list.Init()
+	return tea.Batch(m.list.Init(), m.journalCmd())
 }
 
 func (m *TimeframeScreen) Update(msg tea.Msg) tea.Cmd {
@@ -120,6 +173,34 @@ func (m *TimeframeScreen) Update(msg tea.Msg) tea.Cmd {
 	case ClearMessageMsg:
 		// Clear the message
 		m.message = ""
+	case journalResult:
+		// Journals of a period that was left before they loaded are dropped
+		if msg.timeframe == m.timeframe && msg.period == dates.PeriodString(m.date, m.timeframe) {
+			m.journal = msg.text
+		}
+	case rollForwardPlanned:
+		if len(msg.plan) == 0 {
+			m.message = "Every goal of the period is done"
+			cmds = append(cmds, m.clearMessageAfter(3*time.Second))
+		} else {
+			m.rollForwardPlan = msg.plan
+			m.state = ConfirmRollForward
+		}
+	case rolledForward:
+		m.message = screens.RolledForwardMessage(msg.count)
+		cmds = append(cmds, m.Refresh(), m.clearMessageAfter(3*time.Second))
+	case actionError:
+		m.message = fmt.Sprintf("❌ %s failed: %v", msg.action, msg.err)
+		cmds = append(cmds, m.clearMessageAfter(3*time.Second))
+	case editor.FinishedMsg:
+		if msg.ID == journalEditorID {
+			if msg.Err != nil {
+				m.message = fmt.Sprintf("❌ %v", msg.Err)
+				return m.clearMessageAfter(3 * time.Second)
+			}
+			m.journalCollapsed = false
+			return m.saveJournalCmd(msg.Text)
+		}
 	case error:
 		// swallow errors in UI loop, they will be logged by Bubble Tea
 	}
@@ -135,9 +216,14 @@ func (m *TimeframeScreen) View() string {
 	slice := lipgloss.NewStyle().
 		SetString(m.timeframe.String()).
 		Underline(true).
-		MarginBottom(1).
 		Render()
 
+	workspace := lipgloss.NewStyle().
+		Foreground(theme.TextMuted()).
+		Render("  " + m.workspace)
+
+	slice = lipgloss.NewStyle().MarginBottom(1).Render(slice + workspace)
+
 	date := lipgloss.
 		NewStyle().
 		SetString(dates.DateString(m.date, m.timeframe)).
@@ -145,10 +231,20 @@ func (m *TimeframeScreen) View() string {
 		Bold(true).
 		Render()
 
-	header := lipgloss.NewStyle().MarginBottom(2).PaddingTop(2).Render(lipgloss.JoinVertical(lipgloss.Left, slice, date))
+	headerLines := []string{slice, date}
+	if tagFilter := m.list.TagFilter(); len(tagFilter) > 0 {
+		headerLines = append(headerLines, lipgloss.NewStyle().
+			Foreground(theme.TextMuted()).
+			Render("Filtered by "+goal.TitleWithTags("", tagFilter)))
+	}
+
+	header := lipgloss.NewStyle().MarginBottom(2).PaddingTop(2).Render(lipgloss.JoinVertical(lipgloss.Left, headerLines...))
+	if journal := m.journalView(); journal != "" {
+		header = lipgloss.JoinVertical(lipgloss.Left, header, journal)
+	}
 
 	var actionInput string
-	if m.state == GotoDate {
+	if m.state == GotoDate || m.state == FilterTags {
 		actionInput = lipgloss.JoinHorizontal(
 			lipgloss.Top,
 			lipgloss.
@@ -196,9 +292,11 @@ func (m *TimeframeScreen) View() string {
 
 	var body string
 	switch m.state {
-	case GotoDate:
+	case GotoDate, FilterTags:
 		m.list.SetSize(contentWidth, listHeight)
 		body = m.list.View()
+	case ConfirmRollForward:
+		body = screens.RollForwardPreview(m.rollForwardPlan, contentWidth, listHeight)
 	case Normal:
 		m.list.SetSize(contentWidth, listHeight)
 		body = m.list.View()
@@ -206,7 +304,7 @@ func (m *TimeframeScreen) View() string {
 
 	view := lipgloss.JoinVertical(lipgloss.Left, header, body)
 
-	if m.state == GotoDate {
+	if m.state == GotoDate || m.state == FilterTags {
 		view = lipgloss.JoinVertical(lipgloss.Left, view, actionInput)
 	}
 
@@ -225,14 +323,46 @@ func (m *TimeframeScreen) View() string {
 		Render()
 }
 
+// journalView renders the journal of the period, or its first line while collapsed
+func (m *TimeframeScreen) journalView() string {
+	if m.journal == "" {
+		return ""
+	}
+
+	if m.journalCollapsed {
+		firstLine, _, _ := strings.Cut(m.journal, "\n")
+		line := journalTitleStyle.Render("▸ Journal  ") + firstLine
+		return lipgloss.NewStyle().MaxWidth(min(m.width, maxWidth)).MarginBottom(1).Render(line)
+	}
+
+	text := journalStyle.
+		Width(min(m.width, maxWidth) - 4).
+		MaxHeight(max(m.height/maxJournalHeightRatio, 1)).
+		Render(m.journal)
+
+	return lipgloss.NewStyle().MarginBottom(1).Render(lipgloss.JoinVertical(lipgloss.Left, journalTitleStyle.Render("▾ Journal"), text))
+}
+
+// ShowMessage displays a temporary message under the goals
+func (m *TimeframeScreen) ShowMessage(message string) tea.Cmd {
+	m.message = message
+	return m.clearMessageAfter(3 * time.Second)
+}
+
 func (m *TimeframeScreen) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 }
 
+// IsCapturingInput reports whether keys go to a text input, while a date, tag filter or goal is typed
+func (m *TimeframeScreen) IsCapturingInput() bool {
+	return m.state != Normal || m.list.IsInActiveState()
+}
+
 func (m *TimeframeScreen) Refresh() tea.Cmd {
+	m.workspace = db.Workspace()
 	m.list.SetDate(m.timeframe, m.date)
-	return m.list.RefreshData()
+	return tea.Batch(m.list.RefreshData(), m.journalCmd())
 }
 
 func (m *TimeframeScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
@@ -247,6 +377,10 @@ func (m *TimeframeScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		cmds = append(cmds, m.handleKeyMsgInNormalState(msg))
 	case GotoDate:
 		cmds = append(cmds, m.handleKeyMsgInGotoDateState(msg))
+	case FilterTags:
+		cmds = append(cmds, m.handleKeyMsgInFilterTagsState(msg))
+	case ConfirmRollForward:
+		cmds = append(cmds, m.handleKeyMsgInConfirmRollForwardState(msg))
 	}
 
 	return tea.Batch(cmds...)
@@ -323,6 +457,63 @@ func (m *TimeframeScreen) handleKeyMsgInNormalState(msg tea.KeyMsg) tea.Cmd {
 		}
 	case key.Matches(msg, m.keys.createBackup):
 		return m.createBackupCmd()
+	case key.Matches(msg, m.keys.filterTags):
+		m.actionInput.Placeholder = "#tag, empty to show all"
+		m.actionInput.Prompt = "Filter tags: "
+		if tagFilter := m.list.TagFilter(); len(tagFilter) > 0 {
+			m.actionInput.SetValue(goal.TitleWithTags("", tagFilter) + " ")
+		}
+		m.state = FilterTags
+	case key.Matches(msg, m.keys.openBackups):
+		return func() tea.Msg {
+			return screens.OpenBackupsScreen{}
+		}
+	case key.Matches(msg, m.keys.openInbox):
+		return func() tea.Msg {
+			return screens.OpenInboxScreen{}
+		}
+	case key.Matches(msg, m.keys.openArchive):
+		return func() tea.Msg {
+			return screens.OpenArchiveScreen{}
+		}
+	case key.Matches(msg, m.keys.openWorkspaces):
+		return func() tea.Msg {
+			return screens.OpenWorkspacesScreen{}
+		}
+	case key.Matches(msg, m.keys.openDashboard):
+		return func() tea.Msg {
+			return screens.OpenDashboardScreen{}
+		}
+	case key.Matches(msg, m.keys.openCalendar):
+		date := m.date
+		return func() tea.Msg {
+			return screens.OpenCalendarScreen{Date: date}
+		}
+	case key.Matches(msg, m.keys.openStats):
+		return func() tea.Msg {
+			return screens.OpenStatsScreen{}
+		}
+	case key.Matches(msg, m.keys.editJournal):
+		if m.timeframe == goal.Life {
+			return nil
+		}
+		return editor.Edit(journalEditorID, m.journal)
+	case key.Matches(msg, m.keys.toggleJournal):
+		m.journalCollapsed = !m.journalCollapsed
+	case key.Matches(msg, m.keys.rollForward):
+		if m.timeframe == goal.Life {
+			return nil
+		}
+		return m.planRollForwardCmd()
+	case key.Matches(msg, m.keys.reviewPeriod):
+		// Life goals have no next period to carry goals forward to
+		if m.timeframe == goal.Life {
+			return nil
+		}
+		timeframe, date := m.timeframe, m.date
+		return func() tea.Msg {
+			return screens.OpenReviewScreen{Timeframe: timeframe, Date: date}
+		}
 	}
 	return nil
 }
@@ -350,6 +541,46 @@ func (m *TimeframeScreen) handleKeyMsgInGotoDateState(msg tea.KeyMsg) tea.Cmd {
 	return cmd
 }
 
+func (m *TimeframeScreen) handleKeyMsgInConfirmRollForwardState(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.confirm):
+		plan := m.rollForwardPlan
+		m.rollForwardPlan = nil
+		m.state = Normal
+		return rollForwardCmd(plan)
+	case key.Matches(msg, m.keys.cancel):
+		m.rollForwardPlan = nil
+		m.state = Normal
+	}
+
+	return nil
+}
+
+func (m *TimeframeScreen) handleKeyMsgInFilterTagsState(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.state = Normal
+		m.actionInput.SetValue("")
+		return nil
+	case tea.KeyEnter:
+		m.state = Normal
+		// Accept tags with or without the leading #
+		var tagInput []string
+		for _, word := range strings.Fields(m.actionInput.Value()) {
+			tagInput = append(tagInput, "#"+strings.TrimPrefix(word, "#"))
+		}
+		m.actionInput.SetValue("")
+		_, tags := goal.ParseTags(strings.Join(tagInput, " "))
+		m.list.SetTagFilter(tags)
+		return nil
+	}
+
+	m.actionInput, cmd = m.actionInput.Update(msg)
+	return cmd
+}
+
 func (m *TimeframeScreen) goToParentGoalCmd(parentID string) tea.Cmd {
 	return func() tea.Msg {
 		parentGoal, err := repository.GetGoalByID(parentID)
@@ -405,6 +636,11 @@ func (m *TimeframeScreen) createBackupCmd() tea.Cmd {
 		if err != nil {
 			return BackupError{Error: err}
 		}
+
+		if _, err := db.PruneBackups(); err != nil {
+			return BackupError{Error: err}
+		}
+
 		return BackupSuccess{Path: backupPath}
 	}
 }
@@ -417,3 +653,51 @@ func (m *TimeframeScreen) clearMessageAfter(duration time.Duration) tea.Cmd {
 		return ClearMessageMsg{}
 	})
 }
+
+// journalCmd loads the journal of the shown period. Life goals have no period to keep one for
+func (m *TimeframeScreen) journalCmd() tea.Cmd {
+	timeframe, date := m.timeframe, m.date
+	if timeframe == goal.Life {
+		return nil
+	}
+
+	return func() tea.Msg {
+		text, err := repository.GetPeriodNote(timeframe, date)
+		if err != nil {
+			return err
+		}
+		return journalResult{timeframe: timeframe, period: dates.PeriodString(date, timeframe), text: text}
+	}
+}
+
+func (m *TimeframeScreen) saveJournalCmd(text string) tea.Cmd {
+	timeframe, date := m.timeframe, m.date
+	return func() tea.Msg {
+		if err := repository.SavePeriodNote(timeframe, date, text); err != nil {
+			return actionError{action: "Saving journal", err: err}
+		}
+		return journalResult{timeframe: timeframe, period: dates.PeriodString(date, timeframe), text: text}
+	}
+}
+
+// planRollForwardCmd lists where the unfinished goals of the shown period move
+func (m *TimeframeScreen) planRollForwardCmd() tea.Cmd {
+	timeframe, date := m.timeframe, m.date
+	return func() tea.Msg {
+		goals, err := repository.GetGoalsByDate(timeframe, date)
+		if err != nil {
+			return err
+		}
+		return rollForwardPlanned{plan: repository.PlanRollForward(goals, time.Now())}
+	}
+}
+
+func rollForwardCmd(plan []repository.Postponement) tea.Cmd {
+	return func() tea.Msg {
+		count, err := repository.RollForward(plan)
+		if err != nil {
+			return actionError{action: "Rolling forward", err: err}
+		}
+		return rolledForward{count: count}
+	}
+}
@@ -0,0 +1,40 @@
+package workspaces
+
+import (
+	"hinoki-cli/internal/keymap"
+	"hinoki-cli/internal/screens"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	switchWorkspace key.Binding
+	createWorkspace key.Binding
+	goBack          key.Binding
+}
+
+var (
+	switchWorkspaceAction = keymap.Register("workspaces", "switch", "Switch to workspace", "enter")
+	createWorkspaceAction = keymap.Register("workspaces", "create", "Create workspace", "n")
+)
+
+func newKeyMap() keyMap {
+	return keyMap{
+		switchWorkspace: switchWorkspaceAction.Binding(),
+		createWorkspace: createWorkspaceAction.Binding(),
+		goBack:          screens.GoBackKey(),
+	}
+}
+
+// HelpKeys returns the bindings of the screen for the help overlay
+func (m *WorkspacesScreen) HelpKeys() []screens.KeyGroup {
+	return []screens.KeyGroup{
+		{Title: "Workspaces", Bindings: []key.Binding{
+			m.list.KeyMap.CursorUp,
+			m.list.KeyMap.CursorDown,
+			m.keys.switchWorkspace,
+			m.keys.createWorkspace,
+			m.keys.goBack,
+		}},
+	}
+}
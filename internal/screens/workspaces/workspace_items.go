@@ -0,0 +1,62 @@
+package workspaces
+
+import (
+	"fmt"
+	"hinoki-cli/internal/theme"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type workspaceItem struct {
+	name   string
+	path   string
+	isOpen bool
+}
+
+func (i workspaceItem) FilterValue() string {
+	return i.name
+}
+
+type workspaceItemDelegate struct{}
+
+var (
+	workspaceMetaStyle     = lipgloss.NewStyle().Foreground(theme.TextMuted())
+	workspaceSelectedStyle = theme.SelectedStyle()
+)
+
+func newWorkspaceItemDelegate() list.ItemDelegate {
+	return workspaceItemDelegate{}
+}
+
+func (d workspaceItemDelegate) Height() int { return 2 }
+
+func (d workspaceItemDelegate) Spacing() int { return 1 }
+
+func (d workspaceItemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d workspaceItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(workspaceItem)
+	if !ok {
+		return
+	}
+
+	itemStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary())
+
+	name := item.name
+	if item.isOpen {
+		name += " (open)"
+	}
+	line := fmt.Sprintf("%s\n%s", name, workspaceMetaStyle.Render(item.path))
+
+	if index == m.Index() {
+		itemStyle = workspaceSelectedStyle
+	}
+
+	wrapped := lipgloss.NewStyle().Width(m.Width()).Render(line)
+	fmt.Fprint(w, itemStyle.Render(wrapped))
+}
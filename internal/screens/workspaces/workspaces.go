@@ -0,0 +1,243 @@
+package workspaces
+
+import (
+	"fmt"
+	"strings"
+
+	"hinoki-cli/internal/config"
+	"hinoki-cli/internal/db"
+	"hinoki-cli/internal/repository"
+	"hinoki-cli/internal/screens"
+	"hinoki-cli/internal/theme"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	Normal = iota
+	CreateWorkspace
+)
+
+type State int
+
+type WorkspacesScreen struct {
+	list      list.Model
+	keys      keyMap
+	state     State
+	nameInput textinput.Model
+
+	width, height int
+
+	message string
+}
+
+var (
+	headerStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(theme.TextPrimary()).
+			MarginBottom(2).
+			PaddingTop(2)
+
+	messageStyle = lipgloss.NewStyle().
+			Foreground(theme.TextSecondary()).
+			MarginTop(1).
+			Italic(true)
+
+	nameInputStyle = lipgloss.NewStyle().MarginTop(1).Foreground(theme.TextSecondary())
+)
+
+const (
+	maxWidth = 130
+)
+
+type workspacesResult struct {
+	items []workspaceItem
+}
+
+func NewWorkspacesScreen() screens.Screen {
+	workspaceList := list.New([]list.Item{}, newWorkspaceItemDelegate(), 0, 0)
+	workspaceList.SetShowHelp(false)
+	workspaceList.SetShowStatusBar(false)
+	workspaceList.SetShowTitle(false)
+	workspaceList.SetFilteringEnabled(false)
+	workspaceList.DisableQuitKeybindings()
+
+	workspaceList.KeyMap.CursorUp = screens.CursorUpKey()
+	workspaceList.KeyMap.CursorDown = screens.CursorDownKey()
+
+	nameInput := textinput.New()
+	nameInput.Prompt = "New workspace: "
+	nameInput.Placeholder = "letters, digits, - and _"
+	nameInput.Focus()
+
+	return &WorkspacesScreen{
+		list:      workspaceList,
+		keys:      newKeyMap(),
+		nameInput: nameInput,
+	}
+}
+
+func (m *WorkspacesScreen) Init() tea.Cmd {
+	return m.getWorkspacesCmd()
+}
+
+func (m *WorkspacesScreen) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKeyMsg(msg)
+	case workspacesResult:
+		items := make([]list.Item, 0, len(msg.items))
+		selected := 0
+		for i, item := range msg.items {
+			items = append(items, item)
+			if item.isOpen {
+				selected = i
+			}
+		}
+		m.list.SetItems(items)
+		m.list.Select(selected)
+	case error:
+		m.message = fmt.Sprintf("❌ %v", msg)
+	}
+
+	return nil
+}
+
+func (m *WorkspacesScreen) View() string {
+	header := headerStyle.Render("Workspaces")
+
+	var footer string
+	switch {
+	case m.state == CreateWorkspace:
+		footer = nameInputStyle.Render(m.nameInput.View())
+	case m.message != "":
+		footer = messageStyle.Render(m.message)
+	}
+
+	listHeight := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+
+	style := lipgloss.NewStyle().PaddingLeft(2)
+	horizontalPadding := (m.width - maxWidth) / 2
+
+	if m.width > maxWidth {
+		style = style.PaddingLeft(horizontalPadding).PaddingRight(horizontalPadding)
+	}
+
+	m.list.SetSize(min(m.width, maxWidth), listHeight)
+	view := lipgloss.JoinVertical(lipgloss.Left, header, m.list.View())
+
+	if footer != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, footer)
+	}
+
+	return style.
+		SetString(view).
+		Render()
+}
+
+func (m *WorkspacesScreen) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *WorkspacesScreen) Refresh() tea.Cmd {
+	return m.getWorkspacesCmd()
+}
+
+// IsCapturingInput reports whether the new workspace name is being typed
+func (m *WorkspacesScreen) IsCapturingInput() bool {
+	return m.state == CreateWorkspace
+}
+
+func (m *WorkspacesScreen) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	if m.state == CreateWorkspace {
+		return m.handleKeyMsgInCreateState(msg)
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.goBack):
+		return func() tea.Msg {
+			return screens.GoBack{}
+		}
+	case key.Matches(msg, m.keys.switchWorkspace):
+		if item, ok := m.list.SelectedItem().(workspaceItem); ok {
+			return m.switchWorkspaceCmd(item.name)
+		}
+	case key.Matches(msg, m.keys.createWorkspace):
+		m.state = CreateWorkspace
+		m.message = ""
+		m.nameInput.SetValue("")
+	default:
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Update(msg)
+		return cmd
+	}
+
+	return nil
+}
+
+func (m *WorkspacesScreen) handleKeyMsgInCreateState(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.state = Normal
+		return nil
+	case tea.KeyEnter:
+		name := strings.TrimSpace(m.nameInput.Value())
+		if err := config.ValidateWorkspaceName(name); err != nil {
+			m.message = fmt.Sprintf("❌ %v", err)
+			m.state = Normal
+			return nil
+		}
+		m.state = Normal
+		return m.switchWorkspaceCmd(name)
+	}
+
+	var cmd tea.Cmd
+	m.nameInput, cmd = m.nameInput.Update(msg)
+	return cmd
+}
+
+func (m *WorkspacesScreen) getWorkspacesCmd() tea.Cmd {
+	return func() tea.Msg {
+		names, err := db.ListWorkspaces()
+		if err != nil {
+			return err
+		}
+
+		open := db.Workspace()
+		items := make([]workspaceItem, 0, len(names))
+		for _, name := range names {
+			path, err := db.WorkspacePath(name)
+			if err != nil {
+				return err
+			}
+			items = append(items, workspaceItem{name: name, path: path, isOpen: name == open})
+		}
+		return workspacesResult{items: items}
+	}
+}
+
+// switchWorkspaceCmd opens the database of the workspace and remembers it for the next start.
+// Changes of the previous workspace can no longer be undone
+func (m *WorkspacesScreen) switchWorkspaceCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		if name == db.Workspace() {
+			return screens.GoBack{}
+		}
+
+		if err := db.SwitchWorkspace(name); err != nil {
+			return fmt.Errorf("failed to open workspace %s: %w", name, err)
+		}
+		repository.ClearHistory()
+
+		if err := config.Set("workspace.current", name); err != nil {
+			return screens.WorkspaceSwitched{Message: fmt.Sprintf("❌ Opened workspace %s, but could not remember it for the next start: %v", name, err)}
+		}
+
+		return screens.WorkspaceSwitched{Message: fmt.Sprintf("✅ Opened workspace %s", name)}
+	}
+}
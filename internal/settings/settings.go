@@ -0,0 +1,44 @@
+package settings
+
+import (
+	"fmt"
+	"hinoki-cli/internal/config"
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/theme"
+	"os"
+)
+
+// Apply reads the config file, moving the settings of ~/.hinoki.rc into it on first run,
+// applies the calendar settings and checks the theme. Returns every invalid setting
+func Apply() error {
+	if path, err := config.MigrateLegacy(); err != nil {
+		return err
+	} else if path != "" {
+		fmt.Fprintf(os.Stderr, "Moved settings from ~/.hinoki.rc to %s\n", path)
+	}
+
+	if err := config.Load(); err != nil {
+		return err
+	}
+
+	calendar, err := config.GetCalendarSettings()
+	if err != nil {
+		return err
+	}
+	dates.SetWeekStart(calendar.WeekStart)
+	dates.SetLocale(calendar.Locale)
+
+	// The theme is loaded when the theme package is initialized, so it's only checked here
+	ui, err := config.GetUISettings()
+	if err != nil {
+		return err
+	}
+	if _, err := theme.Resolve(ui); err != nil {
+		if origin := config.Origin("ui.theme"); origin != "" {
+			return fmt.Errorf("%s: %w", origin, err)
+		}
+		return err
+	}
+
+	return nil
+}
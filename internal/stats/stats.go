@@ -0,0 +1,339 @@
+package stats
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"hinoki-cli/internal/dates"
+	"hinoki-cli/internal/goal"
+	"hinoki-cli/internal/repository"
+)
+
+// DefaultPeriods is the number of periods reported when no other number is asked for
+const DefaultPeriods = 8
+
+// Timeframes are the timeframes whose completion is reported, life goals have no periods
+var Timeframes = []goal.Timeframe{goal.Day, goal.Week, goal.Month, goal.Quarter, goal.Year}
+
+// Report is how goals were done over the last periods of every timeframe
+type Report struct {
+	// Periods is the number of periods reported per timeframe, including the current one
+	Periods       int          `json:"periods"`
+	Completion    []Completion `json:"completion"`
+	Streaks       Streaks      `json:"streaks"`
+	Overdue       []Overdue    `json:"overdue"`
+	LifeAlignment []Alignment  `json:"lifeAlignment"`
+}
+
+// Completion lists the done goals of the periods of a timeframe, oldest first
+type Completion struct {
+	Timeframe goal.Timeframe `json:"timeframe"`
+	Periods   []Period       `json:"periods"`
+}
+
+// Sum returns the done and total goals of all periods
+func (c Completion) Sum() (done, total int) {
+	for _, p := range c.Periods {
+		done += p.Done
+		total += p.Total
+	}
+	return done, total
+}
+
+type Period struct {
+	Start string  `json:"start"`
+	Label string  `json:"label"`
+	Done  int     `json:"done"`
+	Total int     `json:"total"`
+	Rate  float64 `json:"rate"`
+}
+
+// Streaks count days on which every day goal was done. Days without day goals don't break a
+// streak, and today only counts once it is complete
+type Streaks struct {
+	Current int `json:"current"`
+	Longest int `json:"longest"`
+}
+
+// Overdue is the number of goals that were overdue at the end of the week ending on Date
+type Overdue struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// Alignment is how many goals of a timeframe have a life goal among their ancestors
+type Alignment struct {
+	Timeframe goal.Timeframe `json:"timeframe"`
+	Linked    int            `json:"linked"`
+	Total     int            `json:"total"`
+	Rate      float64        `json:"rate"`
+}
+
+// Load computes the report of the last periods up to now from the database
+func Load(now time.Time, periods int) (Report, error) {
+	goals, err := repository.GetAllGoals()
+	if err != nil {
+		return Report{}, err
+	}
+
+	statusEvents, err := repository.GetStatusEvents()
+	if err != nil {
+		return Report{}, err
+	}
+
+	return Compute(goals, statusEvents, now, periods), nil
+}
+
+// Compute builds the report of the last periods up to now from all goals, including archived
+// ones, and the status changes of their history by goal, oldest first
+func Compute(goals []goal.Goal, statusEvents map[string][]goal.Event, now time.Time, periods int) Report {
+	report := Report{Periods: periods}
+
+	var active []goal.Goal
+	for _, g := range goals {
+		if !g.IsArchived && g.Timeframe != nil && g.Date != nil {
+			active = append(active, g)
+		}
+	}
+
+	for _, timeframe := range Timeframes {
+		report.Completion = append(report.Completion, completion(active, timeframe, now, periods))
+	}
+
+	report.Streaks = streaks(active, now)
+
+	for week := periods - 1; week >= 0; week-- {
+		end := dates.ChangePeriod(dates.StartOfPeriod(now, goal.Week), goal.Week, 1-week).Add(-time.Nanosecond)
+		moment := end
+		if week == 0 {
+			moment = now
+		}
+		report.Overdue = append(report.Overdue, Overdue{
+			Date:  dates.TimeframeDateString(end),
+			Count: overdueAt(goals, statusEvents, moment),
+		})
+	}
+
+	parents := make(map[string]goal.Goal, len(goals))
+	for _, g := range goals {
+		parents[g.ID] = g
+	}
+	for _, timeframe := range []goal.Timeframe{goal.Day, goal.Week} {
+		report.LifeAlignment = append(report.LifeAlignment, alignment(active, parents, timeframe, now, periods))
+	}
+
+	return report
+}
+
+// periodStarts returns the start of the last periods of a timeframe up to the one containing now,
+// oldest first
+func periodStarts(timeframe goal.Timeframe, now time.Time, periods int) []time.Time {
+	current := dates.StartOfPeriod(now, timeframe)
+
+	starts := make([]time.Time, 0, periods)
+	for i := periods - 1; i >= 0; i-- {
+		starts = append(starts, dates.StartOfPeriod(dates.ChangePeriod(current, timeframe, -i), timeframe))
+	}
+	return starts
+}
+
+func completion(goals []goal.Goal, timeframe goal.Timeframe, now time.Time, periods int) Completion {
+	starts := periodStarts(timeframe, now, periods)
+
+	index := make(map[string]int, len(starts))
+	result := Completion{Timeframe: timeframe, Periods: make([]Period, len(starts))}
+	for i, start := range starts {
+		key := dates.TimeframeDateString(start)
+		index[key] = i
+		result.Periods[i] = Period{Start: key, Label: dates.DateString(start, timeframe)}
+	}
+
+	for _, g := range goals {
+		if *g.Timeframe != timeframe {
+			continue
+		}
+		if i, ok := index[dates.PeriodString(*g.Date, timeframe)]; ok {
+			result.Periods[i].Total++
+			if g.IsDone {
+				result.Periods[i].Done++
+			}
+		}
+	}
+
+	for i := range result.Periods {
+		result.Periods[i].Rate = rate(result.Periods[i].Done, result.Periods[i].Total)
+	}
+
+	return result
+}
+
+func streaks(goals []goal.Goal, now time.Time) Streaks {
+	type dayCount struct{ done, total int }
+
+	today := dates.TimeframeDateString(now)
+	days := make(map[string]dayCount)
+	for _, g := range goals {
+		if *g.Timeframe != goal.Day {
+			continue
+		}
+		day := dates.TimeframeDateString(*g.Date)
+		if day > today {
+			continue
+		}
+		count := days[day]
+		count.total++
+		if g.IsDone {
+			count.done++
+		}
+		days[day] = count
+	}
+
+	keys := make([]string, 0, len(days))
+	for day, count := range days {
+		// Today is still in progress until all of its goals are done
+		if day == today && count.done < count.total {
+			continue
+		}
+		keys = append(keys, day)
+	}
+	sort.Strings(keys)
+
+	var s Streaks
+	run := 0
+	for _, day := range keys {
+		if count := days[day]; count.done == count.total {
+			run++
+			s.Longest = max(s.Longest, run)
+		} else {
+			run = 0
+		}
+	}
+	s.Current = run
+
+	return s
+}
+
+// overdueAt counts the goals that were overdue at the moment: created before it, not archived
+// yet, and not done although their period had passed. Goals are counted in their current period
+func overdueAt(goals []goal.Goal, statusEvents map[string][]goal.Event, moment time.Time) int {
+	count := 0
+	for _, g := range goals {
+		if g.CreatedAt.After(moment) || !dates.IsOverdueAt(g.Date, g.Timeframe, moment) {
+			continue
+		}
+		if g.IsArchived && (g.ArchivedAt == nil || !g.ArchivedAt.After(moment)) {
+			continue
+		}
+		if !doneAt(g, statusEvents[g.ID], moment) {
+			count++
+		}
+	}
+	return count
+}
+
+// doneAt reports whether a goal was done at the moment, from the status changes of its history.
+// Goals done before their history was recorded count as done since their last update
+func doneAt(g goal.Goal, events []goal.Event, moment time.Time) bool {
+	if len(events) == 0 {
+		return g.IsDone && !g.UpdatedAt.After(moment)
+	}
+
+	done := events[0].OldValue == "done"
+	for _, e := range events {
+		if e.CreatedAt.After(moment) {
+			break
+		}
+		done = e.NewValue == "done"
+	}
+	return done
+}
+
+// alignment counts the goals of a timeframe in the reported periods that trace up to a life goal
+func alignment(goals []goal.Goal, all map[string]goal.Goal, timeframe goal.Timeframe, now time.Time, periods int) Alignment {
+	first := dates.TimeframeDateString(periodStarts(timeframe, now, periods)[0])
+	last := dates.PeriodString(now, timeframe)
+
+	result := Alignment{Timeframe: timeframe}
+	for _, g := range goals {
+		if *g.Timeframe != timeframe {
+			continue
+		}
+		if key := dates.PeriodString(*g.Date, timeframe); key < first || key > last {
+			continue
+		}
+
+		result.Total++
+		if hasLifeAncestor(g, all) {
+			result.Linked++
+		}
+	}
+	result.Rate = rate(result.Linked, result.Total)
+
+	return result
+}
+
+func hasLifeAncestor(g goal.Goal, all map[string]goal.Goal) bool {
+	seen := map[string]bool{g.ID: true}
+	for g.ParentId != nil {
+		parent, ok := all[*g.ParentId]
+		if !ok || seen[parent.ID] {
+			return false
+		}
+		if parent.Timeframe != nil && *parent.Timeframe == goal.Life {
+			return true
+		}
+		seen[parent.ID] = true
+		g = parent
+	}
+	return false
+}
+
+func rate(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values from 0 to top as bars of eight heights. Negative values have no data
+// and are drawn as a dot
+func Sparkline(values []float64, top float64) string {
+	var b strings.Builder
+	for _, value := range values {
+		switch {
+		case value < 0:
+			b.WriteRune('·')
+		case top <= 0:
+			b.WriteRune(sparks[0])
+		default:
+			b.WriteRune(sparks[min(int(value/top*float64(len(sparks)-1)+0.5), len(sparks)-1)])
+		}
+	}
+	return b.String()
+}
+
+// Values returns the rates of the periods for Sparkline, without data for periods without goals
+func (c Completion) Values() []float64 {
+	values := make([]float64, len(c.Periods))
+	for i, p := range c.Periods {
+		values[i] = p.Rate
+		if p.Total == 0 {
+			values[i] = -1
+		}
+	}
+	return values
+}
+
+// OverdueValues returns the overdue counts for Sparkline along with the highest count
+func (r Report) OverdueValues() ([]float64, float64) {
+	values := make([]float64, len(r.Overdue))
+	top := 0.0
+	for i, o := range r.Overdue {
+		values[i] = float64(o.Count)
+		top = max(top, values[i])
+	}
+	return values, top
+}
@@ -0,0 +1,108 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"hinoki-cli/internal/goal"
+)
+
+// now is Wednesday 2024-11-20
+var now = time.Date(2024, 11, 20, 18, 0, 0, 0, time.UTC)
+
+func dayGoal(id, date string, done bool) goal.Goal {
+	day, _ := time.Parse("2006-01-02", date)
+	timeframe := goal.Day
+	return goal.Goal{ID: id, Timeframe: &timeframe, Date: &day, IsDone: done, CreatedAt: day, UpdatedAt: day}
+}
+
+func TestCompute_Completion(t *testing.T) {
+	goals := []goal.Goal{
+		dayGoal("a", "2024-11-20", true),
+		dayGoal("b", "2024-11-20", false),
+		dayGoal("c", "2024-11-19", true),
+		dayGoal("old", "2024-11-01", true),
+	}
+
+	report := Compute(goals, nil, now, 3)
+
+	day := report.Completion[0]
+	if day.Timeframe != goal.Day || len(day.Periods) != 3 {
+		t.Fatalf("completion %+v; want 3 day periods", day)
+	}
+	if p := day.Periods[2]; p.Start != "2024-11-20" || p.Done != 1 || p.Total != 2 || p.Rate != 0.5 {
+		t.Errorf("today %+v; want 1/2 done", p)
+	}
+	if p := day.Periods[1]; p.Start != "2024-11-19" || p.Done != 1 || p.Total != 1 {
+		t.Errorf("yesterday %+v; want 1/1 done", p)
+	}
+
+	week := report.Completion[1]
+	if p := week.Periods[2]; p.Start != "2024-11-18" || p.Total != 0 {
+		t.Errorf("week %+v; want no week goals", p)
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	goals := []goal.Goal{
+		dayGoal("a", "2024-11-13", true),
+		dayGoal("b", "2024-11-14", false),
+		dayGoal("c", "2024-11-15", true),
+		dayGoal("d", "2024-11-16", true),
+		// No goals on the 17th
+		dayGoal("e", "2024-11-18", true),
+		dayGoal("f", "2024-11-19", true),
+		// Today is in progress
+		dayGoal("g", "2024-11-20", false),
+	}
+
+	s := streaks(goals, now)
+	if s.Current != 4 || s.Longest != 4 {
+		t.Errorf("streaks %+v; want current and longest 4", s)
+	}
+}
+
+func TestOverdueAt(t *testing.T) {
+	late := dayGoal("late", "2024-11-10", true)
+	events := map[string][]goal.Event{
+		"late": {{Kind: goal.StatusChanged, OldValue: "undone", NewValue: "done", CreatedAt: time.Date(2024, 11, 15, 9, 0, 0, 0, time.UTC)}},
+	}
+
+	goals := []goal.Goal{late, dayGoal("open", "2024-11-12", false)}
+
+	if count := overdueAt(goals, events, time.Date(2024, 11, 14, 0, 0, 0, 0, time.UTC)); count != 2 {
+		t.Errorf("overdue on the 14th = %d; want 2", count)
+	}
+	if count := overdueAt(goals, events, now); count != 1 {
+		t.Errorf("overdue now = %d; want 1", count)
+	}
+}
+
+func TestHasLifeAncestor(t *testing.T) {
+	life, year := goal.Life, goal.Year
+	lifeID, yearID := "life", "year"
+
+	all := map[string]goal.Goal{
+		"life": {ID: "life", Timeframe: &life},
+		"year": {ID: "year", Timeframe: &year, ParentId: &lifeID},
+	}
+
+	linked := dayGoal("a", "2024-11-20", false)
+	linked.ParentId = &yearID
+	if !hasLifeAncestor(linked, all) {
+		t.Error("goal under a year goal under a life goal, want linked")
+	}
+
+	if hasLifeAncestor(dayGoal("b", "2024-11-20", false), all) {
+		t.Error("goal without parent, want not linked")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if line := Sparkline([]float64{0, 0.5, 1, -1}, 1); line != "▁▅█·" {
+		t.Errorf("Sparkline = %q; want ▁▅█·", line)
+	}
+	if line := Sparkline([]float64{0, 0}, 0); line != "▁▁" {
+		t.Errorf("Sparkline without a top = %q; want ▁▁", line)
+	}
+}
@@ -15,33 +15,58 @@ const (
 
 	// Accent colors
 	ColorAccent = "170" // Pink/magenta for selected items
+	ColorRed    = "160" // Red for overdue goals on light backgrounds
+	ColorRose   = "203" // Red for overdue goals on dark backgrounds
 )
 
-// Semantic color functions - adapt to light/dark background
-func TextPrimary() lipgloss.Color {
-	if lipgloss.HasDarkBackground() {
-		return lipgloss.Color(ColorWhite)
-	}
-	return lipgloss.Color(ColorBlack)
+// Semantic color functions - the colors of the configured theme
+func TextPrimary() lipgloss.TerminalColor {
+	return active.Primary
 }
 
-func TextSecondary() lipgloss.Color {
-	if lipgloss.HasDarkBackground() {
-		return lipgloss.Color(ColorGrayLight)
-	}
-	return lipgloss.Color(ColorGrayDark)
+func TextSecondary() lipgloss.TerminalColor {
+	return active.Secondary
+}
+
+func TextMuted() lipgloss.TerminalColor {
+	return active.Muted
 }
 
-func TextMuted() lipgloss.Color {
-	return lipgloss.Color(ColorGrayMedium)
+func TextDisabled() lipgloss.TerminalColor {
+	return active.Disabled
 }
 
-func TextDisabled() lipgloss.Color {
-	return lipgloss.Color(ColorGrayDark)
+func TextSelected() lipgloss.TerminalColor {
+	return active.Selected
+}
+
+// Overdue is the color of goals whose period has passed
+func Overdue() lipgloss.TerminalColor {
+	return active.Overdue
+}
+
+// Done is the color of done goals
+func Done() lipgloss.TerminalColor {
+	return active.Done
+}
+
+// Tag is the color of #tags
+func Tag() lipgloss.TerminalColor {
+	return active.Tag
+}
+
+// SelectedStyle is the style of the selected item of a list. Without colors the item is
+// shown in reverse video instead
+func SelectedStyle() lipgloss.Style {
+	if active.monochrome {
+		return lipgloss.NewStyle().Reverse(true)
+	}
+	return lipgloss.NewStyle().Foreground(active.Selected)
 }
 
-func TextSelected() lipgloss.Color {
-	return lipgloss.Color(ColorAccent)
+// IsMonochrome reports whether colors are turned off, by the monochrome theme or NO_COLOR
+func IsMonochrome() bool {
+	return active.monochrome
 }
 
 // Direct color access (for cases where semantic doesn't fit)
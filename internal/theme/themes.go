@@ -0,0 +1,164 @@
+package theme
+
+import (
+	"fmt"
+	"hinoki-cli/internal/config"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Palette maps the semantic roles of text to colors
+type Palette struct {
+	Primary   lipgloss.TerminalColor
+	Secondary lipgloss.TerminalColor
+	Muted     lipgloss.TerminalColor
+	Disabled  lipgloss.TerminalColor
+	Selected  lipgloss.TerminalColor
+	Overdue   lipgloss.TerminalColor
+	Done      lipgloss.TerminalColor
+	Tag       lipgloss.TerminalColor
+
+	monochrome bool
+}
+
+// colors are the colors of a palette in the order of config.PaletteRoles
+type colors [8]string
+
+var (
+	lightColors = colors{ColorBlack, ColorGrayDark, ColorGrayMedium, ColorGrayDark, ColorAccent, ColorRed, ColorGrayDark, ColorGrayDark}
+	darkColors  = colors{ColorWhite, ColorGrayLight, ColorGrayMedium, ColorGrayDark, ColorAccent, ColorRose, ColorGrayDark, ColorGrayLight}
+
+	highContrastLight = colors{"#000000", "#000000", "#303030", "#585858", "#0000d7", "#d70000", "#008700", "#005f87"}
+	highContrastDark  = colors{"#ffffff", "#ffffff", "#d0d0d0", "#a8a8a8", "#ffff00", "#ff5f5f", "#87ff87", "#5fd7ff"}
+
+	solarizedLight = colors{"#586e75", "#657b83", "#93a1a1", "#93a1a1", "#d33682", "#dc322f", "#93a1a1", "#2aa198"}
+	solarizedDark  = colors{"#93a1a1", "#839496", "#586e75", "#586e75", "#d33682", "#dc322f", "#586e75", "#2aa198"}
+)
+
+// themes are the built-in themes. Themes with a light and a dark variant follow the terminal
+// background
+var themes = map[string]Palette{
+	"auto":          adaptive(lightColors, darkColors),
+	"light":         fixed(lightColors),
+	"dark":          fixed(darkColors),
+	"high-contrast": adaptive(highContrastLight, highContrastDark),
+	"solarized":     adaptive(solarizedLight, solarizedDark),
+	"monochrome":    monochrome(),
+}
+
+// active is the palette of the configured theme. Styles are created in package level variables,
+// so the palette is loaded before any package that imports theme is initialized
+var active = load()
+
+func load() Palette {
+	settings, _ := config.GetUISettings()
+
+	palette, err := Resolve(settings)
+	if err != nil {
+		// The invalid theme is reported by settings.Apply
+		palette = themes["auto"]
+	}
+
+	// NO_COLOR makes lipgloss drop all styling, but without colors the selected item is shown
+	// in reverse video. The roles of the monochrome palette have no colors, so only text
+	// attributes are rendered on a terminal
+	if palette.monochrome && lipgloss.ColorProfile() == termenv.Ascii {
+		if termenv.NewOutput(os.Stdout).ColorProfile() != termenv.Ascii {
+			lipgloss.SetColorProfile(termenv.ANSI)
+		}
+	}
+
+	// Styles that pick colors by the background follow light and dark themes
+	switch base(settings) {
+	case "light":
+		lipgloss.SetHasDarkBackground(false)
+	case "dark":
+		lipgloss.SetHasDarkBackground(true)
+	}
+
+	return palette
+}
+
+// Resolve returns the palette of a theme: a built-in theme, or a [palette.<name>] of the config
+// file that changes some colors of the theme it is based on. NO_COLOR turns off all colors
+func Resolve(settings config.UISettings) (Palette, error) {
+	if os.Getenv("NO_COLOR") != "" {
+		return themes["monochrome"], nil
+	}
+
+	if palette, ok := themes[settings.Theme]; ok {
+		return palette, nil
+	}
+
+	custom, ok := settings.Palettes[settings.Theme]
+	if !ok {
+		return Palette{}, fmt.Errorf("unknown theme %q, themes are %s and the palettes of the config file", settings.Theme, strings.Join(Names(), ", "))
+	}
+
+	palette, ok := themes[base(settings)]
+	if !ok {
+		return Palette{}, fmt.Errorf("palette %s is based on unknown theme %q, themes are %s", settings.Theme, custom["base"], strings.Join(Names(), ", "))
+	}
+
+	for i, role := range config.PaletteRoles {
+		if value, ok := custom[role]; ok {
+			*palette.role(i) = lipgloss.Color(value)
+		}
+	}
+
+	return palette, nil
+}
+
+// Names returns the names of the built-in themes
+func Names() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// base returns the built-in theme a theme is based on, the theme itself for built-in themes
+func base(settings config.UISettings) string {
+	if custom, ok := settings.Palettes[settings.Theme]; ok {
+		if name, ok := custom["base"]; ok {
+			return strings.ToLower(name)
+		}
+		return "auto"
+	}
+	return settings.Theme
+}
+
+func (p *Palette) role(i int) *lipgloss.TerminalColor {
+	return []*lipgloss.TerminalColor{&p.Primary, &p.Secondary, &p.Muted, &p.Disabled, &p.Selected, &p.Overdue, &p.Done, &p.Tag}[i]
+}
+
+func fixed(c colors) Palette {
+	var p Palette
+	for i, color := range c {
+		*p.role(i) = lipgloss.Color(color)
+	}
+	return p
+}
+
+// adaptive picks the light or dark color of every role when rendered
+func adaptive(light, dark colors) Palette {
+	var p Palette
+	for i := range light {
+		*p.role(i) = lipgloss.AdaptiveColor{Light: light[i], Dark: dark[i]}
+	}
+	return p
+}
+
+func monochrome() Palette {
+	p := Palette{monochrome: true}
+	for i := range config.PaletteRoles {
+		*p.role(i) = lipgloss.NoColor{}
+	}
+	return p
+}
@@ -0,0 +1,51 @@
+package theme
+
+import (
+	"hinoki-cli/internal/config"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestResolve(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	settings := config.UISettings{
+		Theme: "forest",
+		Palettes: map[string]map[string]string{
+			"forest": {"base": "dark", "selected": "#87d787"},
+		},
+	}
+
+	palette, err := Resolve(settings)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if palette.Selected != lipgloss.Color("#87d787") {
+		t.Errorf("Selected = %v, want the palette color", palette.Selected)
+	}
+	if palette.Primary != lipgloss.Color(ColorWhite) {
+		t.Errorf("Primary = %v, want the color of the dark theme", palette.Primary)
+	}
+
+	settings.Palettes["forest"]["base"] = "sepia"
+	if _, err := Resolve(settings); err == nil {
+		t.Error("Resolve() with an unknown base, want error")
+	}
+
+	if _, err := Resolve(config.UISettings{Theme: "sepia"}); err == nil {
+		t.Error("Resolve() with an unknown theme, want error")
+	}
+}
+
+func TestResolve_NoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	palette, err := Resolve(config.UISettings{Theme: "solarized"})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if !palette.monochrome {
+		t.Error("NO_COLOR, want the monochrome theme")
+	}
+}
@@ -1,7 +1,26 @@
 package main
 
-import "hinoki-cli/internal"
+import (
+	"flag"
+	"fmt"
+	"hinoki-cli/internal"
+	"hinoki-cli/internal/cli"
+	"os"
+)
 
 func main() {
+	args, err := cli.ParseGlobalFlags(os.Args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "hinoki: %v\n", err)
+		os.Exit(2)
+	}
+
+	if len(args) > 0 {
+		os.Exit(cli.Run(args))
+	}
+
 	internal.CreateApp()
 }